@@ -65,6 +65,28 @@ type CrudStub struct {
 	Collections map[string]*TableRule `json:"collections" yaml:"collections"` // The key here is table name
 	IsPrimary   bool                  `json:"isPrimary" yaml:"isPrimary"`
 	Enabled     bool                  `json:"enabled" yaml:"enabled"`
+	BatchMode   string                `json:"batchMode,omitempty" yaml:"batchMode,omitempty"` // auto, transaction or sequential (mongo only)
+	Replicas    []string              `json:"replicas,omitempty" yaml:"replicas,omitempty"`   // connection strings of the read replicas
+
+	// Connection pool settings. The driver defaults are used for the settings which aren't provided
+	MaxOpenConns     int `json:"maxOpenConns,omitempty" yaml:"maxOpenConns,omitempty"`         // max pool size in mongo
+	MaxIdleConns     int `json:"maxIdleConns,omitempty" yaml:"maxIdleConns,omitempty"`         // sql only
+	ConnMaxLifetime  int `json:"connMaxLifetime,omitempty" yaml:"connMaxLifetime,omitempty"`   // in seconds; sql only
+	StatementTimeout int `json:"statementTimeout,omitempty" yaml:"statementTimeout,omitempty"` // in seconds
+
+	// SlowQueryThreshold is the duration (in milliseconds) after which an operation is logged as slow
+	SlowQueryThreshold int `json:"slowQueryThreshold,omitempty" yaml:"slowQueryThreshold,omitempty"`
+
+	CDC *CDC `json:"cdc,omitempty" yaml:"cdc,omitempty"`
+}
+
+// CDC holds the config of the change data capture source of a database. It is supported on mongo (change streams)
+// and postgres (logical decoding with wal2json). The events logged for such a database carry a change_key, hence the
+// event log table needs a change_key column (preferably indexed) if it is on a sql database
+type CDC struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Slot    string `json:"slot,omitempty" yaml:"slot,omitempty"`     // logical replication slot (postgres only)
+	Plugin  string `json:"plugin,omitempty" yaml:"plugin,omitempty"` // output plugin of the slot (postgres only). Only wal2json is supported
 }
 
 // TableRule contains the config at the collection level
@@ -72,6 +94,27 @@ type TableRule struct {
 	IsRealTimeEnabled bool             `json:"isRealtimeEnabled" yaml:"isRealtimeEnabled"`
 	Rules             map[string]*Rule `json:"rules" yaml:"rules"` // The key here is query, insert, update or delete
 	Schema            string           `json:"schema" yaml:"schema"`
+	SoftDelete        bool             `json:"softDelete,omitempty" yaml:"softDelete,omitempty"` // deletes set the deleted_at field instead of removing the documents
+	Cache             *ReadCache       `json:"cache,omitempty" yaml:"cache,omitempty"`
+	View              *View            `json:"view,omitempty" yaml:"view,omitempty"` // the collection is a read only view
+}
+
+// View holds the definition of a database view backing a collection
+type View struct {
+	Query    string        `json:"query,omitempty" yaml:"query,omitempty"`       // the select statement of sql views
+	Source   string        `json:"source,omitempty" yaml:"source,omitempty"`     // the collection the pipeline of mongo views runs on
+	Pipeline []interface{} `json:"pipeline,omitempty" yaml:"pipeline,omitempty"` // the aggregation pipeline of mongo views
+
+	// Materialized views (postgres only) store the result of the query. They are refreshed on demand or every
+	// refresh interval (in seconds) if it is set
+	Materialized    bool `json:"materialized,omitempty" yaml:"materialized,omitempty"`
+	RefreshInterval int  `json:"refreshInterval,omitempty" yaml:"refreshInterval,omitempty"`
+}
+
+// ReadCache holds the config of the in memory cache of the reads made on a collection
+type ReadCache struct {
+	TTL        int `json:"ttl" yaml:"ttl"`               // in seconds
+	MaxEntries int `json:"maxEntries" yaml:"maxEntries"` // the least recently used reads are evicted beyond this
 }
 
 // Rule is the authorisation object at the query level
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/segmentio/ksuid"
 	"github.com/urfave/cli"
 
 	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/modules/schema"
 	"github.com/spaceuptech/space-cloud/utils"
 	"github.com/spaceuptech/space-cloud/utils/metrics"
 	"github.com/spaceuptech/space-cloud/utils/server"
@@ -128,6 +134,33 @@ var essentialFlags = []cli.Flag{
 	},
 }
 
+var seedFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "config",
+		Value:  "config.yaml",
+		Usage:  "Load space cloud config from `FILE`",
+		EnvVar: "CONFIG",
+	},
+	cli.StringFlag{
+		Name:  "project",
+		Usage: "The project to seed. It can be skipped if the config has a single project",
+	},
+	cli.StringFlag{
+		Name:  "db",
+		Usage: "The alias of the database to seed",
+	},
+	cli.StringFlag{
+		Name:  "file",
+		Value: "fixtures.yaml",
+		Usage: "Load the fixtures (in yaml or json) from `FILE`",
+	},
+	cli.BoolFlag{
+		Name:   "remove-project-scope",
+		Usage:  "Removes the project level scope in the database and file storage modules",
+		EnvVar: "REMOVE_PROJECT_SCOPE",
+	},
+}
+
 func main() {
 	app := cli.NewApp()
 	app.Version = utils.BuildVersion
@@ -146,6 +179,12 @@ func main() {
 			Usage:  "creates a config file with sensible defaults",
 			Action: actionInit,
 		},
+		{
+			Name:   "seed",
+			Usage:  "upserts the documents of the fixtures in a database",
+			Action: actionSeed,
+			Flags:  seedFlags,
+		},
 	}
 
 	err := app.Run(os.Args)
@@ -243,6 +282,81 @@ func actionInit(*cli.Context) error {
 	return config.GenerateConfig("none")
 }
 
+func actionSeed(c *cli.Context) error {
+	configPath := c.String("config")
+	projectID := c.String("project")
+	dbAlias := c.String("db")
+	fixturesPath := c.String("file")
+	removeProjectScope := c.Bool("remove-project-scope")
+
+	conf, err := config.LoadConfigFromFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	// The project can be skipped if the config has a single project
+	var project *config.Project
+	for _, p := range conf.Projects {
+		if p.ID == projectID || (projectID == "" && len(conf.Projects) == 1) {
+			project = p
+		}
+	}
+	if project == nil || project.Modules == nil {
+		return fmt.Errorf("project (%s) not present in config", projectID)
+	}
+	dbStub, p := project.Modules.Crud[dbAlias]
+	if !p {
+		return fmt.Errorf("database (%s) not present in config", dbAlias)
+	}
+
+	data, err := ioutil.ReadFile(fixturesPath)
+	if err != nil {
+		return err
+	}
+	format := "yaml"
+	if strings.HasSuffix(fixturesPath, "json") {
+		format = "json"
+	}
+	fixtures, err := schema.ParseFixtures(data, format)
+	if err != nil {
+		return err
+	}
+
+	// The documents are seeded without triggering any events
+	crudModule := crud.Init(removeProjectScope)
+	crudModule.SetHooks(&model.CrudHooks{
+		Batch: func(ctx context.Context, dbType string, req *model.BatchRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Stage: func(ctx context.Context, intent *model.EventIntent, err error) {},
+	}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	schemaModule := schema.Init(crudModule, removeProjectScope)
+	crudModule.SetSchema(schemaModule)
+	defer crudModule.Close()
+
+	// Only the database being seeded is connected to, so that the other databases of the project needn't be up
+	dbConfig := config.Crud{dbAlias: dbStub}
+	if err := crudModule.SetConfig(project.ID, dbConfig); err != nil {
+		return err
+	}
+	if err := schemaModule.SetConfig(dbConfig, project.ID); err != nil {
+		return err
+	}
+
+	counts, err := schemaModule.Seed(context.Background(), dbAlias, project.ID, fixtures)
+	if err != nil {
+		return err
+	}
+	// A collection may have more than one fixture
+	for _, fixture := range fixtures {
+		if count, p := counts[fixture.Col]; p {
+			fmt.Println("Seeded", count, "documents in", fixture.Col)
+			delete(counts, fixture.Col)
+		}
+	}
+	return nil
+}
+
 func initMissionContol(version string) (string, error) {
 	homeDir := utils.UserHomeDir()
 	uiPath := homeDir + "/.space-cloud/mission-control-v" + version
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // CreateRequest is the http body received for a create request
 type CreateRequest struct {
 	Document  interface{} `json:"doc"`
@@ -20,6 +22,53 @@ type ReadOptions struct {
 	Skip     *int64           `json:"skip"`
 	Limit    *int64           `json:"limit"`
 	Distinct *string          `json:"distinct"`
+
+	// After and Before are the cursors (returned in PageInfo) to read the documents after or before.
+	// They can be used only along with the sort option
+	After  *string `json:"after,omitempty"`
+	Before *string `json:"before,omitempty"`
+
+	// Join includes the related documents of other collections in each document read
+	Join []*JoinOption `json:"join,omitempty"`
+
+	// WithDeleted includes the soft deleted documents of collections with soft delete enabled
+	WithDeleted bool `json:"withDeleted,omitempty"`
+}
+
+// JoinOption describes a collection to be joined with the documents being read
+type JoinOption struct {
+	Col  string            `json:"col"`
+	On   map[string]string `json:"on"`             // The key is the field of the document read and the value is the field of the joined collection
+	As   string            `json:"as,omitempty"`   // The field in which the joined documents are returned. It defaults to col
+	Type string            `json:"type,omitempty"` // The first joined document is returned instead of an array if type is one
+
+	ExcludeDeleted bool `json:"-"` // The soft deleted documents of the joined collection are left out
+}
+
+// PageInfo holds the cursors of the first and the last document returned in a read request
+type PageInfo struct {
+	StartCursor string `json:"startCursor,omitempty"`
+	EndCursor   string `json:"endCursor,omitempty"`
+}
+
+// ExplainResult describes how the database would execute a read request
+type ExplainResult struct {
+	// Query is the sql query for sql databases and the command for mongo
+	Query interface{}   `json:"query"`
+	Args  []interface{} `json:"args,omitempty"`
+
+	// Plan is the output of the explain command of the database
+	Plan interface{} `json:"plan"`
+}
+
+// SlowQuery describes an operation which took longer than the slow query threshold of the database
+type SlowQuery struct {
+	DBAlias   string                 `json:"db"`
+	Col       string                 `json:"col"`
+	Operation string                 `json:"op"`
+	Find      map[string]interface{} `json:"find,omitempty"` // The values of the find clause are redacted
+	Duration  int64                  `json:"duration"`       // in milliseconds
+	Time      time.Time              `json:"time"`
 }
 
 // UpdateRequest is the http body received for an update request
@@ -27,6 +76,10 @@ type UpdateRequest struct {
 	Find      map[string]interface{} `json:"find"`
 	Operation string                 `json:"op"`
 	Update    map[string]interface{} `json:"update"`
+
+	// ConflictKeys are the primary (or unique) keys identifying the row to be upserted. They are
+	// populated by the crud module from the schema of the collection
+	ConflictKeys []string `json:"-"`
 }
 
 // DeleteRequest is the http body received for a delete request
@@ -49,9 +102,20 @@ type AllRequest struct {
 	Find      map[string]interface{} `json:"find"`
 	Update    map[string]interface{} `json:"update"`
 	Type      string                 `json:"type"`
+
+	// ConflictKeys are the primary (or unique) keys identifying the row to be upserted
+	ConflictKeys []string `json:"-"`
+
+	// IsConditional marks the versioned updates which fail if no document is at the version they expect
+	IsConditional bool `json:"-"`
 }
 
 // BatchRequest is the http body for a batch request
 type BatchRequest struct {
 	Requests []AllRequest `json:"reqs"`
+
+	// IsolationLevel is the isolation level of the transaction (read-uncommitted, read-committed,
+	// repeatable-read, snapshot, serializable or linearizable). The database default is used when empty
+	IsolationLevel string `json:"isolationLevel,omitempty"`
+	ReadOnly       bool   `json:"readOnly,omitempty"`
 }
@@ -16,6 +16,10 @@ type EventDocument struct {
 	Retries        int         `structs:"retries" json:"retries" bson:"retries" mapstructure:"retries"`
 	Url            string      `structs:"url" json:"url" bson:"url" mapstructure:"url"`
 	Remark         string      `structs:"remark" json:"remark" bson:"remark" mapstructure:"remark"`
+
+	// ChangeKey identifies the document an event was logged for. It is only set for the databases whose changes are
+	// captured, so that a captured change can be matched with the event space cloud logged for it
+	ChangeKey string `structs:"change_key,omitempty" json:"change_key,omitempty" bson:"change_key,omitempty" mapstructure:"change_key"`
 }
 
 // CloudEventPayload is the the JSON event spec by Cloud Events Specification
@@ -58,3 +62,11 @@ type DatabaseEventMessage struct {
 	Doc    interface{} `json:"doc" mapstructure:"doc"`
 	Find   interface{} `json:"find" mapstructure:"find"`
 }
+
+// DatabaseChange is a change captured from the change stream of a database
+type DatabaseChange struct {
+	Type string                 // The type of the event (DB_INSERT, DB_UPDATE or DB_DELETE)
+	Col  string                 // The collection which was changed
+	Doc  map[string]interface{} // The document after the change. It is nil for deletes
+	Find map[string]interface{} // The keys identifying the document which was changed
+}
@@ -42,5 +42,7 @@ type Message struct {
 
 // LiveQueryOptions is to set the options for realtime requests
 type LiveQueryOptions struct {
-	SkipInitial bool `json:"skipInitial"`
+	SkipInitial bool          `json:"skipInitial"`
+	Join        []*JoinOption `json:"join,omitempty"` // Join is applied to the initial documents only
+	WithDeleted bool          `json:"withDeleted,omitempty"`
 }
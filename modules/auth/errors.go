@@ -10,3 +10,6 @@ var ErrIncorrectRuleFieldType = errors.New("Auth: Incorrect rule field type")
 
 // ErrIncorrectMatch is thrown when the field type of a rule is of incorrect type
 var ErrIncorrectMatch = errors.New("Auth: The two fields do not match")
+
+// ErrJoinFiltersDocuments is thrown when the read rule of a joined collection depends on the documents being read
+var ErrJoinFiltersDocuments = errors.New("Auth: Collections whose read rule depends on the documents being read cannot be joined")
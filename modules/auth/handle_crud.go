@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/spaceuptech/space-cloud/config"
 	"github.com/spaceuptech/space-cloud/model"
@@ -64,6 +65,36 @@ func (m *Module) IsReadOpAuthorised(ctx context.Context, project, dbType, col, t
 		return &PostProcess{}, http.StatusForbidden, err
 	}
 
+	if req.Options == nil || len(req.Options.Join) == 0 {
+		return actions, http.StatusOK, nil
+	}
+
+	// The read rules of the collections being joined need to be satisfied as well
+	for _, join := range req.Options.Join {
+		rule, auth, err := m.authenticateCrudRequest(dbType, join.Col, token, utils.Read)
+		if err != nil {
+			return &PostProcess{}, http.StatusUnauthorized, err
+		}
+
+		// The rule is matched without the find clause of the joined documents. Hence rules which check or
+		// restrict the documents being read cannot be enforced
+		if dependsOnFind(rule) {
+			return &PostProcess{}, http.StatusForbidden, ErrJoinFiltersDocuments
+		}
+
+		args := map[string]interface{}{"op": utils.All, "auth": auth, "find": map[string]interface{}{}, "token": token}
+		joinActions, err := m.matchRule(ctx, project, rule, map[string]interface{}{"args": args}, auth)
+		if err != nil {
+			return &PostProcess{}, http.StatusForbidden, err
+		}
+
+		as := join.As
+		if as == "" {
+			as = join.Col
+		}
+		actions.postProcessAction = append(actions.postProcessAction, PostProcessAction{Action: "join", Field: as, Value: joinActions})
+	}
+
 	return actions, http.StatusOK, nil
 }
 
@@ -159,6 +190,16 @@ func (m *Module) PostProcessMethod(postProcess *PostProcess, result interface{})
 				if err := utils.DeleteValue(field.Field, map[string]interface{}{"res": doc}); err != nil {
 					return err
 				}
+
+			case "join":
+				// The joined documents are processed as per the rules of their own collection
+				obj, ok := doc.(map[string]interface{})
+				if !ok {
+					return errors.New("result is of invalid type")
+				}
+				if err := m.PostProcessMethod(field.Value.(*PostProcess), obj[field.Field]); err != nil {
+					return err
+				}
 			default:
 				err := fmt.Errorf("invalid action (%s) received in post processing read op", field.Action)
 				return err
@@ -199,3 +240,44 @@ func (m *Module) getCrudRule(dbType, col string, query utils.OperationType) (*co
 	}
 	return nil, fmt.Errorf("no rule found for collection %s in database %s", col, dbType)
 }
+
+// dependsOnFind checks if the rule (or any of its clauses) refers to the find clause of the request
+func dependsOnFind(rule *config.Rule) bool {
+	if rule == nil {
+		return false
+	}
+	if refersToFind(rule.F1) || refersToFind(rule.F2) || refersToFind(rule.Field) || refersToFind(rule.Value) || refersToFind(rule.Find) {
+		return true
+	}
+	for _, field := range rule.Fields {
+		if refersToFind(field) {
+			return true
+		}
+	}
+	for _, clause := range rule.Clauses {
+		if dependsOnFind(clause) {
+			return true
+		}
+	}
+	return false
+}
+
+func refersToFind(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(v, "args.find")
+	case map[string]interface{}:
+		for _, val := range v {
+			if refersToFind(val) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			if refersToFind(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
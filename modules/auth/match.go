@@ -62,7 +62,7 @@ func (m *Module) matchFunc(ctx context.Context, rule *config.Rule, MakeHttpReque
 	token := obj["token"].(string)
 	delete(obj, "token")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	scToken, err := m.GetSCAccessToken()
@@ -82,7 +82,7 @@ func matchQuery(ctx context.Context, project string, rule *config.Rule, crud *cr
 	req := &model.ReadRequest{Find: rule.Find, Operation: utils.One}
 
 	// Execute the read request
-	_, err := crud.Read(ctx, rule.DB, project, rule.Col, req)
+	_, _, err := crud.Read(ctx, rule.DB, project, rule.Col, req)
 	return err
 }
 
@@ -0,0 +1,160 @@
+package crud
+
+import (
+	"container/list"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+)
+
+// readCache is a least recently used cache of the results of the reads made on a collection
+type readCache struct {
+	lock       sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // The most recently used entry is at the front
+	generation uint64     // Incremented on every purge
+}
+
+type cacheEntry struct {
+	key       string
+	count     int64
+	result    interface{}
+	expiresAt time.Time
+}
+
+func newReadCache(conf *config.ReadCache) *readCache {
+	return &readCache{
+		ttl:        time.Duration(conf.TTL) * time.Second,
+		maxEntries: conf.MaxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// get returns a copy of the cached result so that the caller is free to modify it
+func (c *readCache) get(key string) (int64, interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, p := c.entries[key]
+	if !p {
+		return 0, nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return 0, nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.count, copyResult(entry.result), true
+}
+
+// getGeneration returns the current generation of the cache. It is to be captured before reading the database
+func (c *readCache) getGeneration() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.generation
+}
+
+// set caches a copy of the result. The result is dropped if the cache got purged since the provided generation, since
+// it may have been read before the write which purged the cache. The least recently used entry is evicted if the
+// cache is full
+func (c *readCache) set(key string, generation uint64, count int64, result interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	entry := &cacheEntry{key: key, count: count, result: copyResult(result), expiresAt: time.Now().Add(c.ttl)}
+	if elem, p := c.entries[key]; p {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// purge removes all the entries of the cache
+func (c *readCache) purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = map[string]*list.Element{}
+	c.order.Init()
+	c.generation++
+}
+
+// getCache returns the read cache of the collection. It must be called with the lock held
+func (m *Module) getCache(dbAlias, col string) *readCache {
+	return m.caches[strings.TrimPrefix(dbAlias, "sql-")][col]
+}
+
+// InvalidateCache removes the cached reads of a collection. It is called whenever the collection gets modified
+func (m *Module) InvalidateCache(dbAlias, col string) {
+	m.RLock()
+	defer m.RUnlock()
+
+	m.invalidateCache(dbAlias, col)
+}
+
+func (m *Module) invalidateCache(dbAlias, col string) {
+	if cache := m.getCache(dbAlias, col); cache != nil {
+		cache.purge()
+	}
+}
+
+// generateCacheKey generates the key identifying a read request. Reads which join other collections aren't cached
+// since changes to the joined collections wouldn't invalidate them
+func generateCacheKey(req *model.ReadRequest) (string, bool) {
+	if req.Options != nil && len(req.Options.Join) > 0 {
+		return "", false
+	}
+
+	data, err := json.Marshal(struct {
+		Find      map[string]interface{} `json:"find"`
+		Operation string                 `json:"op"`
+		Options   *model.ReadOptions     `json:"options"`
+	}{req.Find, req.Operation, req.Options})
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// copyResult makes a deep copy of the documents read
+func copyResult(result interface{}) interface{} {
+	switch v := result.(type) {
+	case map[string]interface{}:
+		doc := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			doc[key] = copyResult(value)
+		}
+		return doc
+	case []interface{}:
+		docs := make([]interface{}, len(v))
+		for i, value := range v {
+			docs[i] = copyResult(value)
+		}
+		return docs
+	default:
+		return v
+	}
+}
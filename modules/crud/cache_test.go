@@ -0,0 +1,140 @@
+package crud
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud/sql"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestReadCache(t *testing.T) {
+	c := newReadCache(&config.ReadCache{TTL: 60, MaxEntries: 2})
+
+	c.set("a", 0, 1, map[string]interface{}{"id": "a"})
+	c.set("b", 0, 1, map[string]interface{}{"id": "b"})
+
+	// Reading an entry makes it the most recently used one
+	if _, _, ok := c.get("a"); !ok {
+		t.Fatal("entry (a) not found")
+	}
+	c.set("c", 0, 1, map[string]interface{}{"id": "c"})
+	if _, _, ok := c.get("b"); ok {
+		t.Error("entry (b) should have been evicted")
+	}
+
+	// Results returned can be modified without affecting the cache
+	_, result, ok := c.get("a")
+	if !ok {
+		t.Fatal("entry (a) not found")
+	}
+	result.(map[string]interface{})["id"] = "modified"
+	if _, result, _ := c.get("a"); result.(map[string]interface{})["id"] != "a" {
+		t.Errorf("cached result got modified to %v", result)
+	}
+
+	// Expired entries aren't returned
+	c.ttl = -time.Second
+	c.set("d", 0, 1, map[string]interface{}{"id": "d"})
+	if _, _, ok := c.get("d"); ok {
+		t.Error("entry (d) should have expired")
+	}
+
+	c.purge()
+	if _, _, ok := c.get("c"); ok {
+		t.Error("entry (c) should have been purged")
+	}
+
+	// Results read before a purge aren't cached after it
+	c.ttl = time.Minute
+	generation := c.getGeneration()
+	c.purge()
+	c.set("e", generation, 1, map[string]interface{}{"id": "e"})
+	if _, _, ok := c.get("e"); ok {
+		t.Error("entry (e) read before the purge should not have been cached")
+	}
+	c.set("e", c.getGeneration(), 1, map[string]interface{}{"id": "e"})
+	if _, _, ok := c.get("e"); !ok {
+		t.Error("entry (e) not found")
+	}
+}
+
+func TestModule_ReadCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-read-cache")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "test.db")
+	s, err := sql.Init(utils.SQLite, true, true, path, utils.PoolConfig{})
+	if err != nil {
+		t.Fatal("could not initialise sqlite:", err)
+	}
+	defer func() { _ = s.Close() }()
+	if err := s.RawBatch(context.Background(), []string{
+		"CREATE TABLE todos (id varchar(50) PRIMARY KEY NOT NULL, text text);",
+		"INSERT INTO todos (id, text) VALUES ('1', 'first'), ('2', 'second');",
+	}); err != nil {
+		t.Fatal("could not create table:", err)
+	}
+
+	m := Init(true)
+	m.SetHooks(&model.CrudHooks{
+		Delete: func(ctx context.Context, dbAlias, col string, req *model.DeleteRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Stage: func(ctx context.Context, intent *model.EventIntent, err error) {},
+	}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := m.SetConfig("project", config.Crud{
+		"sqlite": &config.CrudStub{Enabled: true, Conn: path, Collections: map[string]*config.TableRule{
+			"todos": {Cache: &config.ReadCache{TTL: 60, MaxEntries: 10}},
+		}},
+	}); err != nil {
+		t.Fatal("could not set config:", err)
+	}
+
+	ctx := context.Background()
+	count := func() int64 {
+		result, _, err := m.Read(ctx, "sqlite", "project", "todos", &model.ReadRequest{Operation: utils.Count})
+		if err != nil {
+			t.Fatal("could not read:", err)
+		}
+		return result.(int64)
+	}
+
+	if got := count(); got != 2 {
+		t.Fatalf("read %d documents; wanted 2", got)
+	}
+
+	// Changes made behind the back of the module are not seen till the cache is invalidated
+	if err := s.RawBatch(ctx, []string{"INSERT INTO todos (id, text) VALUES ('3', 'third');"}); err != nil {
+		t.Fatal("could not insert:", err)
+	}
+	if got := count(); got != 2 {
+		t.Errorf("read %d documents; wanted the cached count 2", got)
+	}
+
+	// Deleting via the module invalidates the cache
+	if err := m.Delete(ctx, "sqlite", "project", "todos", &model.DeleteRequest{Find: map[string]interface{}{"id": "1"}, Operation: utils.All}); err != nil {
+		t.Fatal("could not delete:", err)
+	}
+	if got := count(); got != 2 {
+		t.Errorf("read %d documents after delete; wanted 2", got)
+	}
+
+	// So do the events of the other nodes
+	if err := s.RawBatch(ctx, []string{"DELETE FROM todos WHERE id = '2';"}); err != nil {
+		t.Fatal("could not delete:", err)
+	}
+	m.InvalidateCache("sqlite", "todos")
+	if got := count(); got != 1 {
+		t.Errorf("read %d documents after invalidation; wanted 1", got)
+	}
+}
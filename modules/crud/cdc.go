@@ -0,0 +1,46 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/model"
+)
+
+// GetChangeFeeds returns the aliases of the databases whose changes are to be captured
+func (m *Module) GetChangeFeeds() []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	aliases := make([]string, 0, len(m.cdc))
+	for alias := range m.cdc {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// WatchChanges streams the changes made to the database outside space cloud as well. It blocks till the context
+// gets cancelled, the stream fails or the config of the database changes
+func (m *Module) WatchChanges(ctx context.Context, dbAlias, project string, onChange func(*model.DatabaseChange) error) error {
+	m.RLock()
+	cdc, p := m.cdc[strings.TrimPrefix(dbAlias, "sql-")]
+	if !p {
+		m.RUnlock()
+		return fmt.Errorf("change data capture is not enabled for %q", dbAlias)
+	}
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err == nil {
+		err = crud.IsClientSafe()
+	}
+	m.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	return crud.WatchChanges(ctx, project, cdc.Slot, onChange)
+}
@@ -2,11 +2,11 @@ package crud
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/config"
 	"github.com/spaceuptech/space-cloud/model"
@@ -19,16 +19,33 @@ import (
 // Module is the root block providing convenient wrappers
 type Module struct {
 	sync.RWMutex
-	block              Crud
-	dbType             string
-	alias              string
+	blocks             map[string]Crud                  // The key here is the db alias
+	replicas           map[string]*replicaSet           // The key here is the db alias
+	timeouts           map[string]time.Duration         // The key here is the db alias
+	slowThresholds     map[string]time.Duration         // The key here is the db alias
+	cdc                map[string]*config.CDC           // The key here is the db alias as provided in the config
+	softDeletes        map[string]map[string]bool       // The key here is the db alias followed by the collection
+	views              map[string]map[string]bool       // The key here is the db alias followed by the collection
+	caches             map[string]map[string]*readCache // The key here is the db alias followed by the collection
 	primaryDB          string
 	project            string
 	removeProjectScope bool
 
+	// slowQueries holds the operations which took longer than the slow query threshold of their database
+	slowQueries *slowQueryLog
+
 	// Variables to store the hooks
 	hooks      *model.CrudHooks
 	metricHook model.MetricCrudHook
+
+	// schema is used to find the keys identifying the rows to be upserted and the version field of collections
+	schema SchemaModule
+}
+
+// SchemaModule is used by the crud module to look up the schema of a collection
+type SchemaModule interface {
+	GetConflictKeys(dbAlias, col string, find map[string]interface{}) ([]string, bool)
+	GetVersionField(dbAlias, col string) (string, bool)
 }
 
 // Crud abstracts the implementation crud operations of databases
@@ -38,6 +55,7 @@ type Crud interface {
 	Update(ctx context.Context, project, col string, req *model.UpdateRequest) (int64, error)
 	Delete(ctx context.Context, project, col string, req *model.DeleteRequest) (int64, error)
 	Aggregate(ctx context.Context, project, col string, req *model.AggregateRequest) (interface{}, error)
+	Explain(ctx context.Context, project, col string, req *model.ReadRequest) (*model.ExplainResult, error)
 	Batch(ctx context.Context, project string, req *model.BatchRequest) ([]int64, error)
 	DescribeTable(ctc context.Context, project, col string) ([]utils.FieldType, []utils.ForeignKeysType, []utils.IndexType, error)
 	RawExec(ctx context.Context, project string) error
@@ -45,15 +63,31 @@ type Crud interface {
 	DeleteCollection(ctx context.Context, project, col string) error
 	CreateProjectIfNotExist(ctx context.Context, project string) error
 	RawBatch(ctx context.Context, batchedQueries []string) error
+	ApplyMigration(ctx context.Context, project, col string, queries []string, record map[string]interface{}) error
 	GetDBType() utils.DBType
 	IsClientSafe() error
 	Close() error
 	GetConnectionState(ctx context.Context) bool
+	GetPoolStats() utils.PoolStats
+	WatchChanges(ctx context.Context, project, slot string, onChange func(*model.DatabaseChange) error) error
+	SetValidator(ctx context.Context, project, col string, schema map[string]interface{}) error
+	SetView(ctx context.Context, project, col, source string, pipeline []interface{}) error
 }
 
 // Init create a new instance of the Module object
 func Init(removeProjectScope bool) *Module {
-	return &Module{removeProjectScope: removeProjectScope}
+	return &Module{
+		blocks:             map[string]Crud{},
+		replicas:           map[string]*replicaSet{},
+		timeouts:           map[string]time.Duration{},
+		slowThresholds:     map[string]time.Duration{},
+		slowQueries:        newSlowQueryLog(),
+		cdc:                map[string]*config.CDC{},
+		softDeletes:        map[string]map[string]bool{},
+		views:              map[string]map[string]bool{},
+		caches:             map[string]map[string]*readCache{},
+		removeProjectScope: removeProjectScope,
+	}
 }
 
 // SetHooks sets the internal hooks
@@ -62,23 +96,78 @@ func (m *Module) SetHooks(hooks *model.CrudHooks, metricHook model.MetricCrudHoo
 	m.metricHook = metricHook
 }
 
-func (m *Module) initBlock(dbType utils.DBType, enabled bool, connection string) (Crud, error) {
+// SetSchema sets the schema module used to find the keys of the rows to be upserted and the version field of collections
+func (m *Module) SetSchema(schema SchemaModule) {
+	m.schema = schema
+}
+
+// getConflictKeys returns the keys identifying the row to be upserted
+func (m *Module) getConflictKeys(dbAlias, col, op string, find map[string]interface{}) []string {
+	if op != utils.Upsert || m.schema == nil {
+		return nil
+	}
+
+	keys, _ := m.schema.GetConflictKeys(dbAlias, col, find)
+	return keys
+}
+
+func (m *Module) initBlock(stub *config.CrudStub, connection string) (Crud, error) {
+	pool := utils.PoolConfig{
+		MaxOpenConns:    stub.MaxOpenConns,
+		MaxIdleConns:    stub.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(stub.ConnMaxLifetime) * time.Second,
+	}
+
+	dbType := utils.DBType(stub.Type)
 	switch dbType {
 	case utils.Mongo:
-		return mgo.Init(enabled, connection)
+		block, err := mgo.Init(stub.Enabled, connection, utils.BatchMode(stub.BatchMode), pool)
+		if block == nil {
+			// The config of the database is invalid
+			return nil, err
+		}
+		return block, err
 
-	case utils.MySQL, utils.Postgres, utils.SqlServer:
-		return sql.Init(dbType, enabled, m.removeProjectScope, connection)
+	case utils.MySQL, utils.Postgres, utils.SqlServer, utils.SQLite:
+		block, err := sql.Init(dbType, stub.Enabled, m.removeProjectScope, connection, pool)
+		if block == nil {
+			return nil, err
+		}
+		return block, err
 	default:
 		return nil, utils.ErrInvalidParams
 	}
 }
 
-func (m *Module) getCrudBlock(dbType string) (Crud, error) {
-	if m.block != nil {
-		return m.block, nil
+func (m *Module) getCrudBlock(dbAlias string) (Crud, error) {
+	if block, p := m.blocks[strings.TrimPrefix(dbAlias, "sql-")]; p {
+		return block, nil
+	}
+	return nil, fmt.Errorf("crud module not initialized yet for %q", dbAlias)
+}
+
+// withStatementTimeout bounds the context of an operation by the statement timeout of the database. Every operation
+// of the module is bounded this way, hence the callers don't need to bound the database operations themselves
+func (m *Module) withStatementTimeout(ctx context.Context, dbAlias string) (context.Context, context.CancelFunc) {
+	timeout, p := m.timeouts[strings.TrimPrefix(dbAlias, "sql-")]
+	if !p {
+		timeout = utils.DefaultStatementTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// WithRequestTimeout bounds the context of a request by the largest statement timeout of the configured databases
+func (m *Module) WithRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	m.RLock()
+	defer m.RUnlock()
+
+	timeout := utils.DefaultStatementTimeout
+	for _, t := range m.timeouts {
+		if t > timeout {
+			timeout = t
+		}
 	}
-	return nil, fmt.Errorf("crud module not initialized yet for %q", dbType)
+	return context.WithTimeout(ctx, timeout)
 }
 
 // SetConfig set the rules and secret key required by the crud block
@@ -86,47 +175,125 @@ func (m *Module) SetConfig(project string, crud config.Crud) error {
 	m.Lock()
 	defer m.Unlock()
 
-	if len(crud) > 1 {
-		return errors.New("crud module cannot have more than 1 db")
-	}
-
 	m.project = project
 
-	// Close the previous database connection
-	if m.block != nil {
-		m.block.Close()
+	// Close the previous database connections
+	for _, block := range m.blocks {
+		_ = block.Close()
 	}
+	m.closeReplicas()
+	m.blocks = make(map[string]Crud, len(crud))
+	m.replicas = make(map[string]*replicaSet, len(crud))
+	m.timeouts = make(map[string]time.Duration, len(crud))
+	m.slowThresholds = make(map[string]time.Duration, len(crud))
+	m.cdc = make(map[string]*config.CDC, len(crud))
+	m.softDeletes = make(map[string]map[string]bool, len(crud))
+	m.views = make(map[string]map[string]bool, len(crud))
+	m.caches = make(map[string]map[string]*readCache, len(crud))
 
-	// Create a new crud blocks
+	// Create a new crud block for each database. A failing database does not prevent the others from connecting
+	var connErr error
 	for k, v := range crud {
-		var c Crud
-		var err error
+		// The maps are keyed by the alias without the legacy sql- prefix
+		alias := strings.TrimPrefix(k, "sql-")
+
 		if v.Type == "" {
 			v.Type = k
 		}
 
 		v.Type = strings.TrimPrefix(v.Type, "sql-")
-		c, err = m.initBlock(utils.DBType(v.Type), v.Enabled, v.Conn)
-
-		m.dbType = v.Type
-		m.block = c
-		m.alias = strings.TrimPrefix(k, "sql-")
+		if v.StatementTimeout > 0 {
+			m.timeouts[alias] = time.Duration(v.StatementTimeout) * time.Second
+		}
+		if v.SlowQueryThreshold > 0 {
+			m.slowThresholds[alias] = time.Duration(v.SlowQueryThreshold) * time.Millisecond
+		}
+		if v.CDC != nil && v.CDC.Enabled {
+			// The changes are read with the sql functions of logical decoding, which don't work with pgoutput
+			if v.CDC.Plugin != "" && v.CDC.Plugin != "wal2json" {
+				connErr = fmt.Errorf("output plugin (%s) is not supported for change data capture on %s - use wal2json", v.CDC.Plugin, k)
+				log.Println("Error configuring change data capture for " + k + " : " + connErr.Error())
+			} else {
+				m.cdc[alias] = v.CDC
+			}
+		}
+		for col, rule := range v.Collections {
+			if rule == nil {
+				continue
+			}
+			if rule.SoftDelete {
+				if m.softDeletes[alias] == nil {
+					m.softDeletes[alias] = map[string]bool{}
+				}
+				m.softDeletes[alias][col] = true
+			}
+			if rule.View != nil {
+				if m.views[alias] == nil {
+					m.views[alias] = map[string]bool{}
+				}
+				m.views[alias][col] = true
+			}
+			if rule.Cache != nil && rule.Cache.TTL > 0 {
+				if m.caches[alias] == nil {
+					m.caches[alias] = map[string]*readCache{}
+				}
+				m.caches[alias][col] = newReadCache(rule.Cache)
+			}
+		}
 
+		// Blocks which couldn't connect are kept since they reconnect on their next use. Blocks which couldn't be
+		// created at all (e.g. of an unsupported type) are left out
+		c, err := m.initBlock(v, v.Conn)
+		if c != nil {
+			m.blocks[alias] = c
+		}
 		if err != nil {
 			log.Println("Error connecting to " + k + " : " + err.Error())
-			return err
-		} else {
-			log.Println("Successfully connected to " + k)
+			connErr = err
+			continue
 		}
+
+		log.Println("Successfully connected to " + k)
+
+		// Connect to the read replicas. Replicas which fail to connect are retried on their next health check
+		if len(v.Replicas) > 0 {
+			set := &replicaSet{replicas: make([]*replica, 0, len(v.Replicas))}
+			for i, conn := range v.Replicas {
+				r, err := m.initBlock(v, conn)
+				if err != nil {
+					log.Printf("Error connecting to replica %d of %s : %s\n", i, k, err.Error())
+				}
+				if r != nil {
+					set.replicas = append(set.replicas, &replica{block: r})
+				}
+			}
+			m.replicas[alias] = set
+		}
+	}
+	return connErr
+}
+
+// Close closes the connections to all the databases along with their replicas
+func (m *Module) Close() {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, block := range m.blocks {
+		_ = block.Close()
 	}
-	return nil
+	m.closeReplicas()
+	m.blocks = map[string]Crud{}
+	m.replicas = map[string]*replicaSet{}
 }
 
 // GetDBType returns the type of the db for the alias provided
 func (m *Module) GetDBType(dbAlias string) (string, error) {
-	dbAlias = strings.TrimPrefix(dbAlias, "sql-")
-	if dbAlias != m.alias {
-		return "", fmt.Errorf("db (%s) not found", dbAlias)
+	m.RLock()
+	defer m.RUnlock()
+
+	block, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return "", fmt.Errorf("db (%s) not found", strings.TrimPrefix(dbAlias, "sql-"))
 	}
-	return m.dbType, nil
+	return string(block.GetDBType()), nil
 }
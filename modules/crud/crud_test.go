@@ -0,0 +1,86 @@
+package crud
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestModule_SetConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-crud")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	m := Init(true)
+	err = m.SetConfig("project", config.Crud{
+		"sql-sqlite": &config.CrudStub{Type: "sqlite", Enabled: true, Conn: filepath.Join(dir, "test.db"), CDC: &config.CDC{Enabled: true}},
+		"mongo":      &config.CrudStub{Type: "mongo", Enabled: true, BatchMode: "invalid"},
+	})
+	if err == nil {
+		t.Error("SetConfig() succeeded with an invalid database")
+	}
+	defer func() { _ = m.blocks["sqlite"].Close() }()
+
+	// The maps are keyed by the alias without the sql- prefix
+	if got := m.GetChangeFeeds(); !reflect.DeepEqual(got, []string{"sqlite"}) {
+		t.Errorf("GetChangeFeeds() = %v; want [sqlite]", got)
+	}
+	if _, err := m.GetDBType("sql-sqlite"); err != nil {
+		t.Errorf("GetDBType() error = %v for the prefixed alias", err)
+	}
+	if _, err := m.GetDBType("sqlite"); err != nil {
+		t.Errorf("GetDBType() error = %v for the alias", err)
+	}
+
+	// The database which could not be initialised is not stored
+	if _, p := m.blocks["mongo"]; p {
+		t.Error("SetConfig() stored the block of an invalid database")
+	}
+	if err := m.WatchChanges(context.Background(), "mongo", "project", nil); err == nil {
+		t.Error("WatchChanges() succeeded for a database without change data capture")
+	}
+}
+
+func TestModule_Close(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-crud")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	m := Init(true)
+	if err := m.SetConfig("project", config.Crud{"sqlite": &config.CrudStub{Type: "sqlite", Enabled: true, Conn: filepath.Join(dir, "test.db")}}); err != nil {
+		t.Fatal("could not set config:", err)
+	}
+
+	m.Close()
+	if _, err := m.GetCollections(context.Background(), "project", "sqlite"); err == nil {
+		t.Error("GetCollections() succeeded after the module was closed")
+	}
+}
+
+func TestModule_WithRequestTimeout(t *testing.T) {
+	m := Init(true)
+	m.timeouts = map[string]time.Duration{"short": time.Second, "long": time.Minute}
+
+	ctx, cancel := m.WithRequestTimeout(context.Background())
+	defer cancel()
+
+	// The request is bounded by the largest statement timeout
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("WithRequestTimeout() returned a context without a deadline")
+	}
+	if remaining := time.Until(deadline); remaining <= utils.DefaultStatementTimeout || remaining > time.Minute {
+		t.Errorf("WithRequestTimeout() deadline in %v; want about a minute", remaining)
+	}
+}
@@ -0,0 +1,101 @@
+package crud
+
+import (
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// generatePageInfo generates the cursors of the first and the last document of a read request. Cursors
+// are derived from the sort keys, hence they are only generated for sorted reads of all documents
+func generatePageInfo(req *model.ReadRequest, result interface{}) (*model.PageInfo, error) {
+	if req.Operation != utils.All || req.Options == nil || len(req.Options.Sort) == 0 {
+		return nil, nil
+	}
+
+	docs, ok := result.([]interface{})
+	if !ok || len(docs) == 0 {
+		return &model.PageInfo{}, nil
+	}
+
+	first, ok1 := docs[0].(map[string]interface{})
+	last, ok2 := docs[len(docs)-1].(map[string]interface{})
+	if !ok1 || !ok2 {
+		return nil, nil
+	}
+
+	startCursor, err := utils.EncodeCursor(req.Options.Sort, first)
+	if err != nil {
+		return nil, err
+	}
+
+	endCursor, err := utils.EncodeCursor(req.Options.Sort, last)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PageInfo{StartCursor: startCursor, EndCursor: endCursor}, nil
+}
+
+// selectSortKeys makes sure the sort keys of a sorted read get selected, since the cursors are generated from
+// them. The request is copied if it needs to be changed. The keys which weren't selected originally are
+// returned so that they can be removed from the documents read
+func selectSortKeys(req *model.ReadRequest) (*model.ReadRequest, []string) {
+	if req.Operation != utils.All || req.Options == nil || len(req.Options.Sort) == 0 || len(req.Options.Select) == 0 {
+		return req, nil
+	}
+
+	// Mongo treats the select clause as a projection which excludes the fields marked with 0
+	isExclusion := false
+	for _, v := range req.Options.Select {
+		if v == 0 {
+			isExclusion = true
+			break
+		}
+	}
+
+	sel := make(map[string]int32, len(req.Options.Select)+len(req.Options.Sort))
+	for k, v := range req.Options.Select {
+		sel[k] = v
+	}
+
+	var added []string
+	for _, key := range req.Options.Sort {
+		key = strings.TrimPrefix(key, "-")
+		v, p := sel[key]
+		switch {
+		case isExclusion && p && v == 0:
+			delete(sel, key)
+		case !isExclusion && !p:
+			sel[key] = 1
+		default:
+			continue
+		}
+		added = append(added, key)
+	}
+	if len(added) == 0 {
+		return req, nil
+	}
+
+	options := *req.Options
+	options.Select = sel
+	readReq := *req
+	readReq.Options = &options
+	return &readReq, added
+}
+
+// removeFields removes the fields from the documents read
+func removeFields(result interface{}, fields []string) {
+	docs, ok := result.([]interface{})
+	if !ok {
+		return
+	}
+	for _, doc := range docs {
+		if doc, ok := doc.(map[string]interface{}); ok {
+			for _, field := range fields {
+				delete(doc, field)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package crud
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func Test_selectSortKeys(t *testing.T) {
+	var tests = []struct {
+		name       string
+		sel        map[string]int32
+		sort       []string
+		wantSelect map[string]int32
+		wantAdded  []string
+	}{
+		{name: "no select", sort: []string{"age"}},
+		{name: "sort key selected", sel: map[string]int32{"name": 1, "age": 1}, sort: []string{"-age"}, wantSelect: map[string]int32{"name": 1, "age": 1}},
+		{name: "sort key not selected", sel: map[string]int32{"name": 1}, sort: []string{"-age", "id"}, wantSelect: map[string]int32{"name": 1, "age": 1, "id": 1}, wantAdded: []string{"age", "id"}},
+		{name: "sort key excluded", sel: map[string]int32{"name": 0, "age": 0}, sort: []string{"age", "id"}, wantSelect: map[string]int32{"name": 0}, wantAdded: []string{"age"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Select: tt.sel, Sort: tt.sort}}
+			got, added := selectSortKeys(req)
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("selectSortKeys() added = %v; want %v", added, tt.wantAdded)
+			}
+			if tt.wantSelect != nil && !reflect.DeepEqual(got.Options.Select, tt.wantSelect) {
+				t.Errorf("selectSortKeys() select = %v; want %v", got.Options.Select, tt.wantSelect)
+			}
+			if len(tt.wantAdded) > 0 && got == req {
+				t.Error("selectSortKeys() modified the request instead of copying it")
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package crud
+
+import (
+	"context"
+
+	"github.com/spaceuptech/space-cloud/model"
+)
+
+// Explain returns the query generated for a read request along with the plan the database would use to execute it.
+// The request is prepared exactly like a read, which is why the query is explained on the database serving the reads
+func (m *Module) Explain(ctx context.Context, dbAlias, project, col string, req *model.ReadRequest) (*model.ExplainResult, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	if err := prepareJoins(req); err != nil {
+		return nil, err
+	}
+
+	m.excludeDeletedJoins(dbAlias, req)
+	if m.isSoftDelete(dbAlias, col) && (req.Options == nil || !req.Options.WithDeleted) {
+		readReq := *req
+		readReq.Find = excludeDeleted(req.Find)
+		req = &readReq
+	}
+
+	crud, err := m.getReadBlock(ctx, dbAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := crud.IsClientSafe(); err != nil {
+		return nil, err
+	}
+
+	return crud.Explain(ctx, project, col, req)
+}
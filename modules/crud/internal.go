@@ -2,6 +2,7 @@ package crud
 
 import (
 	"context"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/utils"
@@ -13,6 +14,9 @@ func (m *Module) InternalCreate(ctx context.Context, dbAlias, project, col strin
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -22,8 +26,15 @@ func (m *Module) InternalCreate(ctx context.Context, dbAlias, project, col strin
 		return err
 	}
 
+	if err := m.checkWritable(dbAlias, col); err != nil {
+		return err
+	}
+
 	// Perform the create operation
+	start := time.Now()
 	n, err := crud.Create(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Create, nil, start)
+	m.invalidateCache(dbAlias, col)
 
 	// Invoke the metric hook if the operation was successful
 	if err == nil {
@@ -33,12 +44,46 @@ func (m *Module) InternalCreate(ctx context.Context, dbAlias, project, col strin
 	return err
 }
 
+// InternalRead returns the document(s) which match a query from the primary database based on dbAlias.
+// It does not invoke any hooks. This should only be used by the eventing module.
+func (m *Module) InternalRead(ctx context.Context, dbAlias, project, col string, req *model.ReadRequest) (interface{}, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := crud.IsClientSafe(); err != nil {
+		return nil, err
+	}
+
+	// Perform the read operation
+	start := time.Now()
+	n, result, err := crud.Read(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Read, req.Find, start)
+
+	// Invoke the metric hook if the operation was successful
+	if err == nil {
+		m.metricHook(m.project, dbAlias, col, n, utils.Read)
+	}
+
+	return result, err
+}
+
 // InternalUpdate updates the document(s) which match a query from the database based on dbType.
 // It does not invoke any hooks. This should only be used by the eventing module.
 func (m *Module) InternalUpdate(ctx context.Context, dbAlias, project, col string, req *model.UpdateRequest) error {
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -48,8 +93,17 @@ func (m *Module) InternalUpdate(ctx context.Context, dbAlias, project, col strin
 		return err
 	}
 
+	if err := m.checkWritable(dbAlias, col); err != nil {
+		return err
+	}
+
+	req.ConflictKeys = m.getConflictKeys(dbAlias, col, req.Operation, req.Find)
+
 	// Perform the update operation
+	start := time.Now()
 	n, err := crud.Update(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Update, req.Find, start)
+	m.invalidateCache(dbAlias, col)
 
 	// Invoke the metric hook if the operation was successful
 	if err == nil {
@@ -65,6 +119,9 @@ func (m *Module) InternalDelete(ctx context.Context, dbAlias, project, col strin
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -74,8 +131,15 @@ func (m *Module) InternalDelete(ctx context.Context, dbAlias, project, col strin
 		return err
 	}
 
+	if err := m.checkWritable(dbAlias, col); err != nil {
+		return err
+	}
+
 	// Perform the delete operation
+	start := time.Now()
 	n, err := crud.Delete(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Delete, req.Find, start)
+	m.invalidateCache(dbAlias, col)
 
 	// Invoke the metric hook if the operation was successful
 	if err == nil {
@@ -0,0 +1,45 @@
+package crud
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// prepareJoins validates the joins of a read request and fills in their defaults
+func prepareJoins(req *model.ReadRequest) error {
+	if req.Options == nil || len(req.Options.Join) == 0 {
+		return nil
+	}
+
+	if req.Operation != utils.One && req.Operation != utils.All {
+		return fmt.Errorf("join cannot be used with the %s operation", req.Operation)
+	}
+
+	seen := make(map[string]bool, len(req.Options.Join))
+	for _, join := range req.Options.Join {
+		if join == nil || join.Col == "" || len(join.On) == 0 {
+			return errors.New("col and on are required for each join")
+		}
+
+		if join.As == "" {
+			join.As = join.Col
+		}
+		if seen[join.As] {
+			return fmt.Errorf("multiple joins cannot be returned in the same field (%s)", join.As)
+		}
+		seen[join.As] = true
+
+		switch join.Type {
+		case "":
+			join.Type = utils.All
+		case utils.One, utils.All:
+		default:
+			return fmt.Errorf("invalid join type (%s) provided", join.Type)
+		}
+	}
+
+	return nil
+}
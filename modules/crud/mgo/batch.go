@@ -2,61 +2,95 @@ package mgo
 
 import (
 	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/utils"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-// Batch performs the provided operations in a single Batch
+// Batch performs the provided operations in a single Batch. The operations are performed in a transaction
+// if the deployment supports it. Otherwise the batch mode decides whether they are performed sequentially
 func (m *Mongo) Batch(ctx context.Context, project string, txRequest *model.BatchRequest) ([]int64, error) {
-	counts := make([]int64, len(txRequest.Requests))
-	err := m.client.UseSession(ctx, func(session mongo.SessionContext) error {
-		err := session.StartTransaction()
-		if err != nil {
+	useTransaction, err := m.useTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	if !useTransaction {
+		return m.batch(ctx, project, txRequest)
+	}
+
+	var counts []int64
+	err = m.client.UseSession(ctx, func(session mongo.SessionContext) error {
+		if err := session.StartTransaction(); err != nil {
 			return err
 		}
-		for i, req := range txRequest.Requests {
-			col := req.Col
-
-			switch req.Type {
-			case string(utils.Create):
-				doc := req.Document
-				op := req.Operation
-
-				counts[i], err = m.Create(session, project, col, &model.CreateRequest{Document: doc, Operation: op})
-				if err != nil {
-					session.AbortTransaction(session)
-					return err
-				}
-			case string(utils.Update):
-				find := req.Find
-				op := req.Operation
-				update := req.Update
-
-				counts[i], err = m.Update(session, project, col, &model.UpdateRequest{Find: find, Operation: op, Update: update})
-				if err != nil {
-					session.AbortTransaction(session)
-					return err
-				}
-			case string(utils.Delete):
-				find := req.Find
-				op := req.Operation
-
-				counts[i], err = m.Delete(session, project, col, &model.DeleteRequest{Find: find, Operation: op})
-				if err != nil {
-					session.AbortTransaction(session)
-					return err
-				}
+
+		var err error
+		counts, err = m.batch(session, project, txRequest)
+		if err != nil {
+			_ = session.AbortTransaction(session)
+			if batchErr, ok := err.(*utils.BatchError); ok {
+				// None of the requests remain applied once the transaction is aborted
+				batchErr.Counts = nil
 			}
+			return err
 		}
-		err = session.CommitTransaction(session)
-		if err != nil {
-			session.AbortTransaction(session)
+
+		if err := session.CommitTransaction(session); err != nil {
+			_ = session.AbortTransaction(session)
 			return err
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// useTransaction decides whether a batch needs to be performed in a transaction
+func (m *Mongo) useTransaction() (bool, error) {
+	switch m.batchMode {
+	case utils.BatchModeSequential:
+		return false, nil
+	case utils.BatchModeTransaction:
+		if !m.supportsTransactions {
+			return false, errors.New("Mongo deployment does not support transactions. Use a replica set or set the batch mode to auto")
+		}
+		return true, nil
+	default:
+		return m.supportsTransactions, nil
+	}
+}
+
+// batch performs the requests one after the other. It stops at the first request which fails and reports the counts of
+// the requests performed before it
+func (m *Mongo) batch(ctx context.Context, project string, txRequest *model.BatchRequest) ([]int64, error) {
+	counts := make([]int64, len(txRequest.Requests))
+
+	for i, req := range txRequest.Requests {
+		var err error
+		switch req.Type {
+		case string(utils.Create):
+			counts[i], err = m.Create(ctx, project, req.Col, &model.CreateRequest{Document: req.Document, Operation: req.Operation})
+		case string(utils.Update):
+			counts[i], err = m.Update(ctx, project, req.Col, &model.UpdateRequest{Find: req.Find, Operation: req.Operation, Update: req.Update})
+			if err == nil && req.IsConditional && counts[i] == 0 {
+				err = utils.ErrVersionConflict
+			}
+		case string(utils.Delete):
+			counts[i], err = m.Delete(ctx, project, req.Col, &model.DeleteRequest{Find: req.Find, Operation: req.Operation})
+		default:
+			err = utils.ErrInvalidParams
+		}
+		if err != nil {
+			return nil, &utils.BatchError{Index: i, Err: err, Counts: counts[:i]}
+		}
+	}
 
-	return counts, err
+	return counts, nil
 }
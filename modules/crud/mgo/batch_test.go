@@ -0,0 +1,62 @@
+package mgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestMongo_useTransaction(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		batchMode            utils.BatchMode
+		supportsTransactions bool
+		want                 bool
+		wantErr              bool
+	}{
+		{name: "auto with transaction support", batchMode: utils.BatchModeAuto, supportsTransactions: true, want: true},
+		{name: "auto without transaction support", batchMode: utils.BatchModeAuto, supportsTransactions: false, want: false},
+		{name: "transaction with transaction support", batchMode: utils.BatchModeTransaction, supportsTransactions: true, want: true},
+		{name: "transaction without transaction support", batchMode: utils.BatchModeTransaction, supportsTransactions: false, wantErr: true},
+		{name: "sequential", batchMode: utils.BatchModeSequential, supportsTransactions: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Mongo{batchMode: tt.batchMode, supportsTransactions: tt.supportsTransactions}
+			got, err := m.useTransaction()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("useTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("useTransaction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInit_InvalidBatchMode(t *testing.T) {
+	if _, err := Init(false, "", "chaos", utils.PoolConfig{}); err == nil {
+		t.Error("Init() with invalid batch mode must return an error")
+	}
+
+	m, err := Init(false, "", "", utils.PoolConfig{})
+	if err != nil {
+		t.Fatal("Init() error:", err)
+	}
+	if m.batchMode != utils.BatchModeAuto {
+		t.Errorf("Init() batch mode = %s; want %s", m.batchMode, utils.BatchModeAuto)
+	}
+}
+
+func TestInit_UnsupportedPoolSettings(t *testing.T) {
+	for _, pool := range []utils.PoolConfig{{MaxIdleConns: 5}, {ConnMaxLifetime: time.Minute}} {
+		if _, err := Init(false, "", "", pool); err == nil {
+			t.Errorf("Init() with pool settings %+v must return an error", pool)
+		}
+	}
+	if _, err := Init(false, "", "", utils.PoolConfig{MaxOpenConns: 5}); err != nil {
+		t.Error("Init() error:", err)
+	}
+}
@@ -0,0 +1,84 @@
+package mgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// changeEvent is the subset of a change stream event used to generate database events
+type changeEvent struct {
+	OperationType string                 `bson:"operationType"`
+	FullDocument  map[string]interface{} `bson:"fullDocument"`
+	DocumentKey   map[string]interface{} `bson:"documentKey"`
+	Ns            struct {
+		Coll string `bson:"coll"`
+	} `bson:"ns"`
+}
+
+// WatchChanges streams the changes made to the database of the project using a change stream. It blocks till the
+// context gets cancelled or the stream fails. The stream resumes from the last change seen when called again
+func (m *Mongo) WatchChanges(ctx context.Context, project, _ string, onChange func(*model.DatabaseChange) error) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken := m.getResumeToken(); resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	pipeline := []bson.M{{"$match": bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}}}
+	stream, err := m.client.Database(project).Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close(context.Background()) }()
+
+	for stream.Next(ctx) {
+		event := new(changeEvent)
+		if err := stream.Decode(event); err != nil {
+			return err
+		}
+
+		// The stream resumes from the change which failed when watched again
+		if change := generateDatabaseChange(event); change != nil {
+			if err := onChange(change); err != nil {
+				return err
+			}
+		}
+		m.setResumeToken(stream.ResumeToken())
+	}
+
+	return stream.Err()
+}
+
+func (m *Mongo) getResumeToken() bson.Raw {
+	m.resumeLock.Lock()
+	defer m.resumeLock.Unlock()
+
+	return m.resumeToken
+}
+
+func (m *Mongo) setResumeToken(token bson.Raw) {
+	m.resumeLock.Lock()
+	defer m.resumeLock.Unlock()
+
+	m.resumeToken = token
+}
+
+func generateDatabaseChange(event *changeEvent) *model.DatabaseChange {
+	change := &model.DatabaseChange{Col: event.Ns.Coll, Doc: event.FullDocument, Find: event.DocumentKey}
+	switch event.OperationType {
+	case "insert":
+		change.Type = utils.EventDBCreate
+	case "update", "replace":
+		change.Type = utils.EventDBUpdate
+	case "delete":
+		change.Type = utils.EventDBDelete
+		change.Doc = nil
+	default:
+		return nil
+	}
+	return change
+}
@@ -0,0 +1,36 @@
+package mgo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateDatabaseChange(t *testing.T) {
+	doc := map[string]interface{}{"_id": "1", "text": "first"}
+	key := map[string]interface{}{"_id": "1"}
+
+	var tests = []struct {
+		operation string
+		want      *model.DatabaseChange
+	}{
+		{operation: "insert", want: &model.DatabaseChange{Type: utils.EventDBCreate, Col: "todos", Doc: doc, Find: key}},
+		{operation: "update", want: &model.DatabaseChange{Type: utils.EventDBUpdate, Col: "todos", Doc: doc, Find: key}},
+		{operation: "replace", want: &model.DatabaseChange{Type: utils.EventDBUpdate, Col: "todos", Doc: doc, Find: key}},
+		{operation: "delete", want: &model.DatabaseChange{Type: utils.EventDBDelete, Col: "todos", Find: key}},
+		{operation: "drop"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.operation, func(t *testing.T) {
+			event := &changeEvent{OperationType: test.operation, FullDocument: doc, DocumentKey: key}
+			event.Ns.Coll = "todos"
+
+			if got := generateDatabaseChange(event); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("generateDatabaseChange() = %v; want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -37,6 +37,6 @@ func (m *Mongo) Delete(ctx context.Context, project, col string, req *model.Dele
 }
 
 // DeleteCollection removes a collection from database`
-func (m Mongo) DeleteCollection(ctx context.Context, project, col string) error {
+func (m *Mongo) DeleteCollection(ctx context.Context, project, col string) error {
 	return m.client.Database(project).Collection(col, &options.CollectionOptions{}).Drop(ctx)
 }
@@ -2,12 +2,289 @@ package mgo
 
 import (
 	"context"
-	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
-// DescribeTable return a structure of sql table
+// DescribeSampleSize is the number of documents sampled to infer the structure of a collection
+var DescribeSampleSize = 100
+
+// DescribeTable infers the structure of a collection from a sample of its documents. The fields of nested objects
+// are described by their path (e.g. address.city) and the types are the bson types of the values (e.g. objectId,
+// array<string>). Fields holding values of different types are described as mixed
 func (m *Mongo) DescribeTable(ctx context.Context, project, col string) ([]utils.FieldType, []utils.ForeignKeysType, []utils.IndexType, error) {
-	return nil, nil, nil, errors.New("schema operation cannot be performed")
+	collection := m.client.Database(project).Collection(col)
+
+	cursor, err := collection.Aggregate(ctx, bson.A{bson.M{"$sample": bson.M{"size": DescribeSampleSize}}})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var docs []map[string]interface{}
+	for cursor.Next(ctx) {
+		var doc map[string]interface{}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, nil, nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	indexes, err := m.describeIndexes(ctx, project, col)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return describeDocuments(docs), []utils.ForeignKeysType{}, indexes, nil
+}
+
+// describeIndexes returns the fields of the indexes of the collection. The default index on _id is skipped
+func (m *Mongo) describeIndexes(ctx context.Context, project, col string) ([]utils.IndexType, error) {
+	cursor, err := m.client.Database(project).Collection(col).Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	indexes := []utils.IndexType{}
+	for cursor.Next(ctx) {
+		var spec struct {
+			Name   string `bson:"name"`
+			Key    bson.D `bson:"key"`
+			Unique bool   `bson:"unique"`
+		}
+		if err := cursor.Decode(&spec); err != nil {
+			return nil, err
+		}
+		if spec.Name == "_id_" {
+			continue
+		}
+		indexes = append(indexes, describeIndex(col, spec.Name, spec.Key, spec.Unique)...)
+	}
+	return indexes, cursor.Err()
+}
+
+func describeIndex(col, name string, key bson.D, unique bool) []utils.IndexType {
+	isUnique := "no"
+	if unique {
+		isUnique = "yes"
+	}
+
+	indexes := make([]utils.IndexType, len(key))
+	for i, elem := range key {
+		// Special indexes (like text indexes) have strings in place of the sort order
+		sortOrder := "asc"
+		if number, ok := toFloat(elem.Value); ok && number < 0 {
+			sortOrder = "desc"
+		}
+		indexes[i] = utils.IndexType{TableName: col, ColumnName: elem.Key, IndexName: name, Order: i + 1, Sort: sortOrder, IsUnique: isUnique}
+	}
+	return indexes
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// describer accumulates the types of the fields found in the sampled documents
+type describer struct {
+	types   map[string]string // key is the path of the field
+	counts  map[string]int    // the number of non null values of a field
+	objects map[string]int    // the number of objects found at a path. The documents themselves are at the empty path
+}
+
+// describeDocuments infers the fields of a collection from its documents. A field is marked as not nullable only if
+// it holds a value in each of the objects it belongs to
+func describeDocuments(docs []map[string]interface{}) []utils.FieldType {
+	d := &describer{types: map[string]string{}, counts: map[string]int{}, objects: map[string]int{}}
+	for _, doc := range docs {
+		d.describeObject("", doc)
+	}
+
+	paths := make([]string, 0, len(d.types))
+	for path := range d.types {
+		// The fields of an object holding values of other types in some documents are part of a mixed value
+		if d.isNested(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	fields := make([]utils.FieldType, len(paths))
+	for i, path := range paths {
+		typeName := d.types[path]
+		switch typeName {
+		case "":
+			typeName = "mixed"
+		case "array<>":
+			typeName = "array<mixed>"
+		}
+
+		fields[i] = utils.FieldType{FieldName: path, FieldType: typeName, FieldNull: "YES"}
+		if d.counts[path] == d.objects[parentPath(path)] {
+			fields[i].FieldNull = "NO"
+		}
+		if path == "_id" {
+			fields[i].FieldKey = "PRI"
+		}
+	}
+	return fields
+}
+
+// isNested checks if all the ancestors of the field at the path are objects or arrays of objects
+func (d *describer) isNested(path string) bool {
+	for parent := parentPath(path); parent != ""; parent = parentPath(parent) {
+		if typeName := d.types[parent]; typeName != "object" && typeName != "array<object>" {
+			return false
+		}
+	}
+	return true
+}
+
+func parentPath(path string) string {
+	if index := strings.LastIndex(path, "."); index != -1 {
+		return path[:index]
+	}
+	return ""
+}
+
+func (d *describer) describeObject(path string, doc interface{}) {
+	d.objects[path]++
+
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			d.describeField(prefix+key, value)
+		}
+	case primitive.M:
+		for key, value := range v {
+			d.describeField(prefix+key, value)
+		}
+	case primitive.D:
+		for _, elem := range v {
+			d.describeField(prefix+elem.Key, elem.Value)
+		}
+	}
+}
+
+func (d *describer) describeField(path string, value interface{}) {
+	typeName, ok := d.valueType(path, value)
+	current, seen := d.types[path]
+	if !seen {
+		d.types[path] = ""
+	}
+	if !ok {
+		// Null values only make the field nullable
+		return
+	}
+
+	d.counts[path]++
+	if !seen || current == "" {
+		d.types[path] = typeName
+		return
+	}
+	d.types[path] = mergeTypes(current, typeName)
+}
+
+// valueType returns the type of a value. The fields of nested objects (including the objects in arrays) are
+// described along the way. It returns false for null values
+func (d *describer) valueType(path string, value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil, primitive.Null, primitive.Undefined:
+		return "", false
+	case primitive.ObjectID:
+		return "objectId", true
+	case string:
+		return "string", true
+	case int32, int:
+		return "int", true
+	case int64:
+		return "long", true
+	case float64:
+		return "double", true
+	case primitive.Decimal128:
+		return "decimal", true
+	case bool:
+		return "bool", true
+	case primitive.DateTime, primitive.Timestamp, time.Time:
+		return "date", true
+	case map[string]interface{}, primitive.M, primitive.D:
+		d.describeObject(path, v)
+		return "object", true
+	case primitive.A:
+		return d.arrayType(path, v), true
+	case []interface{}:
+		return d.arrayType(path, v), true
+	}
+	return "mixed", true
+}
+
+func (d *describer) arrayType(path string, values []interface{}) string {
+	elemType := ""
+	for _, value := range values {
+		typeName, ok := d.valueType(path, value)
+		if !ok {
+			continue
+		}
+		if elemType == "" {
+			elemType = typeName
+			continue
+		}
+		elemType = mergeTypes(elemType, typeName)
+	}
+	return "array<" + elemType + ">"
+}
+
+// mergeTypes returns the type able to hold the values of both the types
+func mergeTypes(a, b string) string {
+	if a == b {
+		return a
+	}
+
+	// The element type of empty arrays isn't known
+	if strings.HasPrefix(a, "array<") && strings.HasPrefix(b, "array<") {
+		elemA, elemB := a[len("array<"):len(a)-1], b[len("array<"):len(b)-1]
+		switch {
+		case elemA == "":
+			return b
+		case elemB == "":
+			return a
+		}
+		return "array<" + mergeTypes(elemA, elemB) + ">"
+	}
+
+	numbers := map[string]int{"int": 1, "long": 2, "double": 3, "decimal": 3}
+	rankA, isNumberA := numbers[a]
+	rankB, isNumberB := numbers[b]
+	if isNumberA && isNumberB {
+		if rankA == 3 || rankB == 3 {
+			return "double"
+		}
+		return "long"
+	}
+	return "mixed"
 }
@@ -0,0 +1,83 @@
+package mgo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestDescribeDocuments(t *testing.T) {
+	docs := []map[string]interface{}{
+		{
+			"_id":     primitive.NewObjectID(),
+			"name":    "alice",
+			"age":     int32(30),
+			"score":   int64(10),
+			"tags":    primitive.A{"a", "b"},
+			"address": primitive.D{{Key: "city", Value: "Pune"}, {Key: "zip", Value: int32(411001)}},
+			"meta":    "text",
+			"notes":   primitive.A{primitive.D{{Key: "text", Value: "hi"}}},
+			"extra":   primitive.D{{Key: "y", Value: "s"}},
+		},
+		{
+			"_id":     primitive.NewObjectID(),
+			"name":    nil,
+			"age":     int32(20),
+			"score":   1.5,
+			"tags":    primitive.A{},
+			"address": primitive.D{{Key: "city", Value: "Mumbai"}},
+			"meta":    int32(1),
+			"notes":   primitive.A{primitive.D{{Key: "text", Value: "hello"}}, primitive.D{}},
+			"extra":   "str",
+		},
+	}
+
+	want := []utils.FieldType{
+		{FieldName: "_id", FieldType: "objectId", FieldNull: "NO", FieldKey: "PRI"},
+		{FieldName: "address", FieldType: "object", FieldNull: "NO"},
+		{FieldName: "address.city", FieldType: "string", FieldNull: "NO"},
+		{FieldName: "address.zip", FieldType: "int", FieldNull: "YES"},
+		{FieldName: "age", FieldType: "int", FieldNull: "NO"},
+		{FieldName: "extra", FieldType: "mixed", FieldNull: "NO"},
+		{FieldName: "meta", FieldType: "mixed", FieldNull: "NO"},
+		{FieldName: "name", FieldType: "string", FieldNull: "YES"},
+		{FieldName: "notes", FieldType: "array<object>", FieldNull: "NO"},
+		{FieldName: "notes.text", FieldType: "string", FieldNull: "YES"},
+		{FieldName: "score", FieldType: "double", FieldNull: "NO"},
+		{FieldName: "tags", FieldType: "array<string>", FieldNull: "NO"},
+	}
+	if got := describeDocuments(docs); !reflect.DeepEqual(got, want) {
+		t.Errorf("describeDocuments() = %v; want %v", got, want)
+	}
+}
+
+func TestDescribeIndex(t *testing.T) {
+	got := describeIndex("users", "name_age", bson.D{{Key: "name", Value: int32(1)}, {Key: "age", Value: float64(-1)}}, true)
+	want := []utils.IndexType{
+		{TableName: "users", ColumnName: "name", IndexName: "name_age", Order: 1, Sort: "asc", IsUnique: "yes"},
+		{TableName: "users", ColumnName: "age", IndexName: "name_age", Order: 2, Sort: "desc", IsUnique: "yes"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("describeIndex() = %v; want %v", got, want)
+	}
+}
+
+func TestMergeTypes(t *testing.T) {
+	tests := []struct{ a, b, want string }{
+		{a: "int", b: "long", want: "long"},
+		{a: "long", b: "double", want: "double"},
+		{a: "string", b: "int", want: "mixed"},
+		{a: "array<>", b: "array<string>", want: "array<string>"},
+		{a: "array<int>", b: "array<double>", want: "array<double>"},
+		{a: "array<int>", b: "string", want: "mixed"},
+	}
+	for _, tt := range tests {
+		if got := mergeTypes(tt.a, tt.b); got != tt.want {
+			t.Errorf("mergeTypes(%s, %s) = %s; want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
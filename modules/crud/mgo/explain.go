@@ -0,0 +1,80 @@
+package mgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// Explain returns the command generated for a read request along with the plan the database would use to execute it
+func (m *Mongo) Explain(ctx context.Context, project, col string, req *model.ReadRequest) (*model.ExplainResult, error) {
+	command, err := generateReadCommand(col, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan map[string]interface{}
+	explain := bson.D{{Key: "explain", Value: command}, {Key: "verbosity", Value: "queryPlanner"}}
+	if err := m.client.Database(project).RunCommand(ctx, explain).Decode(&plan); err != nil {
+		return nil, err
+	}
+
+	// The command is returned as a map since an ordered document isn't marshalled to a readable JSON
+	return &model.ExplainResult{Query: command.Map(), Plan: plan}, nil
+}
+
+// generateReadCommand generates the database command equivalent to the read request
+func generateReadCommand(col string, req *model.ReadRequest) (bson.D, error) {
+	find := req.Find
+	if find == nil {
+		find = map[string]interface{}{}
+	}
+
+	switch req.Operation {
+	case utils.Count:
+		return bson.D{{Key: "count", Value: col}, {Key: "query", Value: find}}, nil
+
+	case utils.Distinct:
+		if req.Options == nil || req.Options.Distinct == nil {
+			return nil, utils.ErrInvalidParams
+		}
+		return bson.D{{Key: "distinct", Value: col}, {Key: "key", Value: *req.Options.Distinct}, {Key: "query", Value: find}}, nil
+
+	case utils.One, utils.All:
+		find, sort, err := generateCursorFind(find, req.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		// Joins are performed with an aggregation pipeline
+		if req.Options != nil && len(req.Options.Join) > 0 {
+			return bson.D{{Key: "aggregate", Value: col}, {Key: "pipeline", Value: generateJoinPipeline(find, sort, req)}, {Key: "cursor", Value: bson.M{}}}, nil
+		}
+
+		command := bson.D{{Key: "find", Value: col}, {Key: "filter", Value: find}}
+		if req.Options != nil {
+			if req.Options.Select != nil {
+				command = append(command, bson.E{Key: "projection", Value: req.Options.Select})
+			}
+			if sort != nil {
+				command = append(command, bson.E{Key: "sort", Value: generateSortOptions(sort)})
+			}
+			if req.Options.Skip != nil {
+				command = append(command, bson.E{Key: "skip", Value: *req.Options.Skip})
+			}
+			if req.Options.Limit != nil && req.Operation == utils.All {
+				command = append(command, bson.E{Key: "limit", Value: *req.Options.Limit})
+			}
+		}
+		if req.Operation == utils.One {
+			command = append(command, bson.E{Key: "limit", Value: 1})
+		}
+		return command, nil
+
+	default:
+		return nil, utils.ErrInvalidParams
+	}
+}
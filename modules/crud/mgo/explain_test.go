@@ -0,0 +1,69 @@
+package mgo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateReadCommand(t *testing.T) {
+	limit := int64(10)
+	distinct := "owner"
+	tests := []struct {
+		name    string
+		req     *model.ReadRequest
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name: "count",
+			req:  &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{"done": true}},
+			want: bson.D{{Key: "count", Value: "todos"}, {Key: "query", Value: map[string]interface{}{"done": true}}},
+		},
+		{
+			name: "distinct",
+			req:  &model.ReadRequest{Operation: utils.Distinct, Options: &model.ReadOptions{Distinct: &distinct}},
+			want: bson.D{{Key: "distinct", Value: "todos"}, {Key: "key", Value: "owner"}, {Key: "query", Value: map[string]interface{}{}}},
+		},
+		{
+			name:    "distinct without a key",
+			req:     &model.ReadRequest{Operation: utils.Distinct},
+			wantErr: true,
+		},
+		{
+			name: "all",
+			req: &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"done": false}, Options: &model.ReadOptions{
+				Select: map[string]int32{"text": 1},
+				Sort:   []string{"-priority"},
+				Limit:  &limit,
+			}},
+			want: bson.D{
+				{Key: "find", Value: "todos"},
+				{Key: "filter", Value: map[string]interface{}{"done": false}},
+				{Key: "projection", Value: map[string]int32{"text": 1}},
+				{Key: "sort", Value: bson.D{{Key: "priority", Value: -1}}},
+				{Key: "limit", Value: int64(10)},
+			},
+		},
+		{
+			name: "one",
+			req:  &model.ReadRequest{Operation: utils.One, Find: map[string]interface{}{"_id": "1"}},
+			want: bson.D{{Key: "find", Value: "todos"}, {Key: "filter", Value: map[string]interface{}{"_id": "1"}}, {Key: "limit", Value: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateReadCommand("todos", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateReadCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generateReadCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package mgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// readWithJoins reads the documents along with the documents of the joined collections using $lookup
+func (m *Mongo) readWithJoins(ctx context.Context, collection *mongo.Collection, req *model.ReadRequest) (int64, interface{}, error) {
+	find, sort, err := generateCursorFind(req.Find, req.Options)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cur, err := collection.Aggregate(ctx, generateJoinPipeline(find, sort, req))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cur.Close(ctx)
+
+	results := []interface{}{}
+	for cur.Next(ctx) {
+		var doc map[string]interface{}
+		if err := cur.Decode(&doc); err != nil {
+			return 0, nil, err
+		}
+
+		for _, join := range req.Options.Join {
+			if value, p := doc[join.As]; p {
+				doc[join.As] = toJoinedDocs(value)
+			}
+		}
+		results = append(results, doc)
+	}
+
+	if err := cur.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	// Documents before a cursor are read in the reverse order
+	if req.Options.Before != nil {
+		utils.ReverseDocs(results)
+	}
+
+	if req.Operation == utils.One {
+		if len(results) == 0 {
+			return 0, nil, mongo.ErrNoDocuments
+		}
+		return 1, results[0], nil
+	}
+	return int64(len(results)), results, nil
+}
+
+// generateJoinPipeline makes the aggregation pipeline which reads the documents matching the find clause and
+// looks up the documents of the joined collections
+func generateJoinPipeline(find map[string]interface{}, sort []string, req *model.ReadRequest) []bson.M {
+	if find == nil {
+		find = map[string]interface{}{}
+	}
+	pipeline := []bson.M{{"$match": find}}
+
+	opts := req.Options
+	if sort != nil {
+		pipeline = append(pipeline, bson.M{"$sort": generateSortOptions(sort)})
+	}
+	if opts.Skip != nil {
+		pipeline = append(pipeline, bson.M{"$skip": *opts.Skip})
+	}
+	if req.Operation == utils.One {
+		pipeline = append(pipeline, bson.M{"$limit": 1})
+	} else if opts.Limit != nil {
+		pipeline = append(pipeline, bson.M{"$limit": *opts.Limit})
+	}
+
+	for _, join := range opts.Join {
+		pipeline = append(pipeline, generateLookupStage(join))
+		if join.Type == utils.One {
+			pipeline = append(pipeline, bson.M{"$addFields": bson.M{join.As: bson.M{"$arrayElemAt": bson.A{"$" + join.As, 0}}}})
+		}
+	}
+
+	// The fields are selected after the lookup since it may need fields which aren't selected
+	if len(opts.Select) > 0 {
+		project := bson.M{}
+		isInclusion := false
+		for k, v := range opts.Select {
+			project[k] = v
+			if v != 0 {
+				isInclusion = true
+			}
+		}
+		if isInclusion {
+			for _, join := range opts.Join {
+				project[join.As] = 1
+			}
+		}
+		pipeline = append(pipeline, bson.M{"$project": project})
+	}
+
+	return pipeline
+}
+
+// generateLookupStage makes the $lookup stage for a join. Joins on multiple fields are performed with a pipeline
+func generateLookupStage(join *model.JoinOption) bson.M {
+	fields := make([]string, 0, len(join.On))
+	for field := range join.On {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	// The soft deleted documents can only be left out with a pipeline
+	if len(fields) == 1 && !join.ExcludeDeleted {
+		return bson.M{"$lookup": bson.M{"from": join.Col, "localField": fields[0], "foreignField": join.On[fields[0]], "as": join.As}}
+	}
+
+	let := bson.M{}
+	conditions := bson.A{}
+	for i, field := range fields {
+		variable := fmt.Sprintf("v%d", i)
+		let[variable] = "$" + field
+		conditions = append(conditions, bson.M{"$eq": bson.A{"$" + join.On[field], "$$" + variable}})
+	}
+
+	match := bson.M{"$expr": bson.M{"$and": conditions}}
+	if join.ExcludeDeleted {
+		match[utils.SoftDeleteField] = nil
+	}
+
+	return bson.M{"$lookup": bson.M{
+		"from":     join.Col,
+		"let":      let,
+		"pipeline": bson.A{bson.M{"$match": match}},
+		"as":       join.As,
+	}}
+}
+
+// toJoinedDocs converts the joined documents to plain maps and arrays so that they can be post processed like the
+// documents being read
+func toJoinedDocs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.A:
+		docs := make([]interface{}, len(v))
+		for i, doc := range v {
+			docs[i] = toJoinedDocs(doc)
+		}
+		return docs
+	case primitive.D:
+		return map[string]interface{}(v.Map())
+	default:
+		return v
+	}
+}
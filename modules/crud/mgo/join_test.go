@@ -0,0 +1,67 @@
+package mgo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateJoinPipeline(t *testing.T) {
+	limit := int64(10)
+	req := &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{
+		Select: map[string]int32{"text": 1},
+		Limit:  &limit,
+		Join: []*model.JoinOption{
+			{Col: "notes", On: map[string]string{"_id": "todoId"}, As: "notes", Type: utils.All},
+			{Col: "users", On: map[string]string{"owner": "_id", "team": "team"}, As: "user", Type: utils.One},
+		},
+	}}
+
+	got := generateJoinPipeline(map[string]interface{}{"done": false}, []string{"-priority"}, req)
+	want := []bson.M{
+		{"$match": map[string]interface{}{"done": false}},
+		{"$sort": bson.D{{Key: "priority", Value: -1}}},
+		{"$limit": int64(10)},
+		{"$lookup": bson.M{"from": "notes", "localField": "_id", "foreignField": "todoId", "as": "notes"}},
+		{"$lookup": bson.M{
+			"from": "users",
+			"let":  bson.M{"v0": "$owner", "v1": "$team"},
+			"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{"$_id", "$$v0"}},
+				bson.M{"$eq": bson.A{"$team", "$$v1"}},
+			}}}}},
+			"as": "user",
+		}},
+		{"$addFields": bson.M{"user": bson.M{"$arrayElemAt": bson.A{"$user", 0}}}},
+		{"$project": bson.M{"text": int32(1), "notes": 1, "user": 1}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateJoinPipeline() = %v; want %v", got, want)
+	}
+
+	// The soft deleted documents of the joined collection are left out with a pipeline
+	gotStage := generateLookupStage(&model.JoinOption{Col: "notes", On: map[string]string{"_id": "todoId"}, As: "notes", ExcludeDeleted: true})
+	wantStage := bson.M{"$lookup": bson.M{
+		"from":     "notes",
+		"let":      bson.M{"v0": "$_id"},
+		"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{bson.M{"$eq": bson.A{"$todoId", "$$v0"}}}}, "deleted_at": nil}}},
+		"as":       "notes",
+	}}
+	if !reflect.DeepEqual(gotStage, wantStage) {
+		t.Errorf("generateLookupStage() = %v; want %v", gotStage, wantStage)
+	}
+}
+
+func TestToJoinedDocs(t *testing.T) {
+	got := toJoinedDocs(primitive.A{primitive.D{{Key: "a", Value: 1}}})
+	want := []interface{}{map[string]interface{}{"a": 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toJoinedDocs() = %v; want %v", got, want)
+	}
+}
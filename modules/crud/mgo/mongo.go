@@ -2,9 +2,13 @@ package mgo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"sync"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -16,11 +20,37 @@ type Mongo struct {
 	enabled    bool
 	connection string
 	client     *mongo.Client
+
+	// batchMode decides whether batch requests are performed in a transaction
+	batchMode utils.BatchMode
+
+	// supportsTransactions is set if the deployment connected to supports multi document transactions
+	supportsTransactions bool
+
+	pool      utils.PoolConfig
+	poolStats *poolStats
+
+	// resumeToken is the token of the last change read from the change stream
+	resumeLock  sync.Mutex
+	resumeToken bson.Raw
 }
 
 // Init initialises a new mongo instance
-func Init(enabled bool, connection string) (mongoStub *Mongo, err error) {
-	mongoStub = &Mongo{enabled: enabled, connection: connection, client: nil}
+func Init(enabled bool, connection string, batchMode utils.BatchMode, pool utils.PoolConfig) (mongoStub *Mongo, err error) {
+	mongoStub = &Mongo{enabled: enabled, connection: connection, client: nil, batchMode: batchMode, pool: pool, poolStats: &poolStats{}}
+
+	switch batchMode {
+	case "":
+		mongoStub.batchMode = utils.BatchModeAuto
+	case utils.BatchModeAuto, utils.BatchModeTransaction, utils.BatchModeSequential:
+	default:
+		return nil, fmt.Errorf("invalid batch mode (%s) provided for mongo", batchMode)
+	}
+
+	// The mongo driver only lets the max pool size be configured
+	if pool.MaxIdleConns > 0 || pool.ConnMaxLifetime > 0 {
+		return nil, errors.New("maxIdleConns and connMaxLifetime are not supported for mongo")
+	}
 
 	if mongoStub.enabled {
 		err = mongoStub.connect()
@@ -60,7 +90,14 @@ func (m *Mongo) connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
 	defer cancel()
 
-	client, err := mongo.NewClient(options.Client().ApplyURI(m.connection))
+	opts := options.Client().ApplyURI(m.connection).SetPoolMonitor(m.poolStats.monitor())
+
+	// Apply the pool settings which have been provided
+	if m.pool.MaxOpenConns > 0 {
+		opts.SetMaxPoolSize(uint64(m.pool.MaxOpenConns))
+	}
+
+	client, err := mongo.NewClient(opts)
 	if err != nil {
 		return err
 	}
@@ -74,9 +111,33 @@ func (m *Mongo) connect() error {
 	}
 
 	m.client = client
+	m.supportsTransactions = isTransactionSupported(ctx, client)
 	return nil
 }
 
+// isTransactionSupported checks whether the deployment is a replica set (mongo 4.0+) or a
+// sharded cluster (mongo 4.2+), the only deployments which support multi document transactions
+func isTransactionSupported(ctx context.Context, client *mongo.Client) bool {
+	var result struct {
+		SetName        string `bson:"setName"`
+		Msg            string `bson:"msg"`
+		MaxWireVersion int32  `bson:"maxWireVersion"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&result); err != nil {
+		log.Println("Error checking transaction support of mongo:", err)
+		return false
+	}
+
+	switch {
+	case result.Msg == "isdbgrid":
+		return result.MaxWireVersion >= 8
+	case result.SetName != "":
+		return result.MaxWireVersion >= 7
+	default:
+		return false
+	}
+}
+
 // GetDBAlias returns the dbType of the crud block
 func (m *Mongo) GetDBType() utils.DBType {
 	return utils.Mongo
@@ -0,0 +1,42 @@
+package mgo
+
+import (
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// poolStats tracks the connections of the pool using the events emitted by the driver
+type poolStats struct {
+	open  int64
+	inUse int64
+}
+
+// monitor returns the pool monitor which keeps the stats up to date
+func (p *poolStats) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: func(e *event.PoolEvent) {
+		switch e.Type {
+		case event.ConnectionCreated:
+			atomic.AddInt64(&p.open, 1)
+		case event.ConnectionClosed:
+			atomic.AddInt64(&p.open, -1)
+		case event.GetSucceeded:
+			atomic.AddInt64(&p.inUse, 1)
+		case event.ConnectionReturned:
+			atomic.AddInt64(&p.inUse, -1)
+		}
+	}}
+}
+
+// GetPoolStats returns the current state of the connection pool
+func (m *Mongo) GetPoolStats() utils.PoolStats {
+	open, inUse := atomic.LoadInt64(&m.poolStats.open), atomic.LoadInt64(&m.poolStats.inUse)
+	return utils.PoolStats{
+		MaxOpenConns: m.pool.MaxOpenConns,
+		OpenConns:    int(open),
+		InUse:        int(inUse),
+		Idle:         int(open - inUse),
+	}
+}
@@ -11,6 +11,11 @@ func (m *Mongo) RawBatch(ctx context.Context, queries []string) error {
 	return errors.New("raw batch operation cannot be performed on mongo")
 }
 
+// ApplyMigration runs the queries of a schema migration. Mongo collections don't have a schema to be migrated
+func (m *Mongo) ApplyMigration(ctx context.Context, project, col string, queries []string, record map[string]interface{}) error {
+	return errors.New("schema migrations cannot be applied on mongo")
+}
+
 // RawExec performs an operation for schema creation
 // NOTE: not to be exposed externally
 func (m *Mongo) RawExec(ctx context.Context, query string) error {
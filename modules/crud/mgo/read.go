@@ -16,6 +16,11 @@ import (
 func (m *Mongo) Read(ctx context.Context, project, col string, req *model.ReadRequest) (int64, interface{}, error) {
 	collection := m.client.Database(project).Collection(col)
 
+	// Joins are performed with an aggregation pipeline
+	if (req.Operation == utils.One || req.Operation == utils.All) && req.Options != nil && len(req.Options.Join) > 0 {
+		return m.readWithJoins(ctx, collection, req)
+	}
+
 	switch req.Operation {
 	case utils.Count:
 		countOptions := options.Count()
@@ -51,6 +56,11 @@ func (m *Mongo) Read(ctx context.Context, project, col string, req *model.ReadRe
 	case utils.All:
 		findOptions := options.Find()
 
+		find, sort, err := generateCursorFind(req.Find, req.Options)
+		if err != nil {
+			return 0, nil, err
+		}
+
 		if req.Options != nil {
 			if req.Options.Select != nil {
 				findOptions = findOptions.SetProjection(req.Options.Select)
@@ -64,13 +74,13 @@ func (m *Mongo) Read(ctx context.Context, project, col string, req *model.ReadRe
 				findOptions = findOptions.SetLimit(*req.Options.Limit)
 			}
 
-			if req.Options.Sort != nil {
-				findOptions = findOptions.SetSort(generateSortOptions(req.Options.Sort))
+			if sort != nil {
+				findOptions = findOptions.SetSort(generateSortOptions(sort))
 			}
 		}
 
 		results := []interface{}{}
-		cur, err := collection.Find(ctx, req.Find, findOptions)
+		cur, err := collection.Find(ctx, find, findOptions)
 		if err != nil {
 			return 0, nil, err
 		}
@@ -97,11 +107,21 @@ func (m *Mongo) Read(ctx context.Context, project, col string, req *model.ReadRe
 			return 0, nil, err
 		}
 
+		// Documents before a cursor are read in the reverse order
+		if req.Options != nil && req.Options.Before != nil {
+			utils.ReverseDocs(results)
+		}
+
 		return count, results, nil
 
 	case utils.One:
 		findOneOptions := options.FindOne()
 
+		find, sort, err := generateCursorFind(req.Find, req.Options)
+		if err != nil {
+			return 0, nil, err
+		}
+
 		if req.Options != nil {
 			if req.Options.Select != nil {
 				findOneOptions = findOneOptions.SetProjection(req.Options.Select)
@@ -111,14 +131,13 @@ func (m *Mongo) Read(ctx context.Context, project, col string, req *model.ReadRe
 				findOneOptions = findOneOptions.SetSkip(*req.Options.Skip)
 			}
 
-			if req.Options.Sort != nil {
-				findOneOptions = findOneOptions.SetSort(generateSortOptions(req.Options.Sort))
+			if sort != nil {
+				findOneOptions = findOneOptions.SetSort(generateSortOptions(sort))
 			}
 		}
 
 		var res map[string]interface{}
-		err := collection.FindOne(ctx, req.Find, findOneOptions).Decode(&res)
-		if err != nil {
+		if err := collection.FindOne(ctx, find, findOneOptions).Decode(&res); err != nil {
 			return 0, nil, err
 		}
 
@@ -141,3 +160,24 @@ func generateSortOptions(array []string) bson.D {
 
 	return sort
 }
+
+// generateCursorFind adds the filter for the after or before cursor to the find clause. It also
+// returns the sort keys to be used for the query
+func generateCursorFind(find map[string]interface{}, opts *model.ReadOptions) (map[string]interface{}, []string, error) {
+	if opts == nil {
+		return find, nil, nil
+	}
+	if opts.After == nil && opts.Before == nil {
+		return find, opts.Sort, nil
+	}
+
+	cursorFind, sort, err := utils.GenerateCursorFilter(opts.Sort, opts.After, opts.Before)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(find) == 0 {
+		return cursorFind, sort, nil
+	}
+	return map[string]interface{}{"$and": []interface{}{find, cursorFind}}, sort, nil
+}
@@ -15,12 +15,13 @@ func (m *Mongo) Update(ctx context.Context, project, col string, req *model.Upda
 
 	switch req.Operation {
 	case utils.One:
-		_, err := collection.UpdateOne(ctx, req.Find, req.Update)
+		res, err := collection.UpdateOne(ctx, req.Find, req.Update)
 		if err != nil {
 			return 0, err
 		}
 
-		return 1, nil
+		// The matched count (rather than 1) tells a versioned update which didn't find its version apart
+		return res.MatchedCount, nil
 
 	case utils.All:
 		res, err := collection.UpdateMany(ctx, req.Find, req.Update)
@@ -0,0 +1,24 @@
+package mgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// errNamespaceNotFound is the code of the error returned while modifying a collection which doesn't exist
+const errNamespaceNotFound = 26
+
+// SetValidator sets the json schema validating the documents written to the collection. The collection is created if
+// it doesn't exist already
+func (m *Mongo) SetValidator(ctx context.Context, project, col string, schema map[string]interface{}) error {
+	db := m.client.Database(project)
+	validator := bson.M{"$jsonSchema": schema}
+
+	err := db.RunCommand(ctx, bson.D{{Key: "collMod", Value: col}, {Key: "validator", Value: validator}}).Err()
+	if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Code == errNamespaceNotFound {
+		return db.RunCommand(ctx, bson.D{{Key: "create", Value: col}, {Key: "validator", Value: validator}}).Err()
+	}
+	return err
+}
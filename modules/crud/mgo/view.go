@@ -0,0 +1,42 @@
+package mgo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SetView creates a view running the pipeline on the source collection. An existing view is replaced while an
+// existing collection is never dropped
+func (m *Mongo) SetView(ctx context.Context, project, col, source string, pipeline []interface{}) error {
+	db := m.client.Database(project)
+
+	cursor, err := db.ListCollections(ctx, bson.M{"name": col})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+	for cursor.Next(ctx) {
+		var spec struct {
+			Type string `bson:"type"`
+		}
+		if err := cursor.Decode(&spec); err != nil {
+			return err
+		}
+		if spec.Type != "view" {
+			return fmt.Errorf("collection (%s) already exists and is not a view", col)
+		}
+		if err := db.Collection(col).Drop(ctx); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if pipeline == nil {
+		pipeline = []interface{}{}
+	}
+	return db.RunCommand(ctx, bson.D{{Key: "create", Value: col}, {Key: "viewOn", Value: source}, {Key: "pipeline", Value: pipeline}}).Err()
+}
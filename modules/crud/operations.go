@@ -2,6 +2,7 @@ package crud
 
 import (
 	"context"
+	"time"
 
 	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/utils"
@@ -12,6 +13,9 @@ func (m *Module) Create(ctx context.Context, dbAlias, project, col string, req *
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -21,6 +25,10 @@ func (m *Module) Create(ctx context.Context, dbAlias, project, col string, req *
 		return err
 	}
 
+	if err := m.checkWritable(dbAlias, col); err != nil {
+		return err
+	}
+
 	// Invoke the create intent hook
 	intent, err := m.hooks.Create(ctx, dbAlias, col, req)
 	if err != nil {
@@ -28,7 +36,10 @@ func (m *Module) Create(ctx context.Context, dbAlias, project, col string, req *
 	}
 
 	// Perform the create operation
+	start := time.Now()
 	n, err := crud.Create(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Create, nil, start)
+	m.invalidateCache(dbAlias, col)
 
 	// Invoke the metric hook if the operation was successful
 	if err == nil {
@@ -40,28 +51,81 @@ func (m *Module) Create(ctx context.Context, dbAlias, project, col string, req *
 	return err
 }
 
-// Read returns the document(s) which match a query from the database based on dbType
-func (m *Module) Read(ctx context.Context, dbAlias, project, col string, req *model.ReadRequest) (interface{}, error) {
+// Read returns the document(s) which match a query from the database based on dbType. The cursors of the
+// first and the last document are returned as well when the documents are sorted. Reads are served by a
+// replica if the database has any
+func (m *Module) Read(ctx context.Context, dbAlias, project, col string, req *model.ReadRequest) (interface{}, *model.PageInfo, error) {
 	m.RLock()
 	defer m.RUnlock()
 
-	crud, err := m.getCrudBlock(dbAlias)
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	if err := prepareJoins(req); err != nil {
+		return nil, nil, err
+	}
+
+	// Soft deleted documents are left out unless asked for
+	m.excludeDeletedJoins(dbAlias, req)
+	if m.isSoftDelete(dbAlias, col) && (req.Options == nil || !req.Options.WithDeleted) {
+		readReq := *req
+		readReq.Find = excludeDeleted(req.Find)
+		req = &readReq
+	}
+
+	// The sort keys are needed to generate the cursors even if they weren't selected
+	req, addedFields := selectSortKeys(req)
+
+	crud, err := m.getReadBlock(ctx, dbAlias)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := crud.IsClientSafe(); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Serve the read from the cache of the collection if it has one
+	cache := m.getCache(dbAlias, col)
+	cacheKey, isCacheable := "", false
+	if cache != nil {
+		cacheKey, isCacheable = generateCacheKey(req)
+	}
+	var generation uint64
+	if isCacheable {
+		if _, result, ok := cache.get(cacheKey); ok {
+			pageInfo, err := generatePageInfo(req, result)
+			if err != nil {
+				return nil, nil, err
+			}
+			removeFields(result, addedFields)
+			return result, pageInfo, nil
+		}
+		generation = cache.getGeneration()
 	}
 
+	start := time.Now()
 	n, result, err := crud.Read(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Read, req.Find, start)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Replicas may lag behind the primary, hence only the reads served by the primary get cached
+	if primary, _ := m.getCrudBlock(dbAlias); isCacheable && crud == primary {
+		cache.set(cacheKey, generation, n, result)
+	}
 
 	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		m.metricHook(m.project, dbAlias, col, n, utils.Read)
+	m.metricHook(m.project, dbAlias, col, n, utils.Read)
+
+	pageInfo, err := generatePageInfo(req, result)
+	if err != nil {
+		return nil, nil, err
 	}
+	removeFields(result, addedFields)
 
-	return result, err
+	return result, pageInfo, nil
 }
 
 // Update updates the document(s) which match a query from the database based on dbType
@@ -69,6 +133,9 @@ func (m *Module) Update(ctx context.Context, dbAlias, project, col string, req *
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -78,6 +145,24 @@ func (m *Module) Update(ctx context.Context, dbAlias, project, col string, req *
 		return err
 	}
 
+	if err := m.checkWritable(dbAlias, col); err != nil {
+		return err
+	}
+
+	// Soft deleted documents are not updated. Upserts are left as is since they would insert a duplicate of the
+	// soft deleted document otherwise
+	if m.isSoftDelete(dbAlias, col) && req.Operation != utils.Upsert {
+		req.Find = excludeDeleted(req.Find)
+	}
+
+	req.ConflictKeys = m.getConflictKeys(dbAlias, col, req.Operation, req.Find)
+
+	// Versioned documents are updated only if they are still at the version the request expects
+	isConditional, err := m.prepareVersionedUpdate(dbAlias, col, req)
+	if err != nil {
+		return err
+	}
+
 	// Invoke the update intent hook
 	intent, err := m.hooks.Update(ctx, dbAlias, col, req)
 	if err != nil {
@@ -85,7 +170,13 @@ func (m *Module) Update(ctx context.Context, dbAlias, project, col string, req *
 	}
 
 	// Perform the update operation
+	start := time.Now()
 	n, err := crud.Update(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Update, req.Find, start)
+	m.invalidateCache(dbAlias, col)
+	if err == nil && isConditional && n == 0 {
+		err = utils.ErrVersionConflict
+	}
 
 	// Invoke the metric hook if the operation was successful
 	if err == nil {
@@ -102,6 +193,9 @@ func (m *Module) Delete(ctx context.Context, dbAlias, project, col string, req *
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -111,14 +205,26 @@ func (m *Module) Delete(ctx context.Context, dbAlias, project, col string, req *
 		return err
 	}
 
+	if err := m.checkWritable(dbAlias, col); err != nil {
+		return err
+	}
+
 	// Invoke the delete intent hook
 	intent, err := m.hooks.Delete(ctx, dbAlias, col, req)
 	if err != nil {
 		return err
 	}
 
-	// Perform the delete operation
-	n, err := crud.Delete(ctx, project, col, req)
+	// Perform the delete operation. Collections with soft delete only mark the documents as deleted
+	var n int64
+	start := time.Now()
+	if m.isSoftDelete(dbAlias, col) {
+		n, err = crud.Update(ctx, project, col, generateSoftDeleteRequest(crud.GetDBType(), req.Find, req.Operation))
+	} else {
+		n, err = crud.Delete(ctx, project, col, req)
+	}
+	m.trackQuery(dbAlias, col, utils.Delete, req.Find, start)
+	m.invalidateCache(dbAlias, col)
 
 	// Invoke the metric hook if the operation was successful
 	if err == nil {
@@ -130,12 +236,16 @@ func (m *Module) Delete(ctx context.Context, dbAlias, project, col string, req *
 	return err
 }
 
-// Aggregate performs an aggregation defined via the pipeline
+// Aggregate performs an aggregation defined via the pipeline. Aggregations are served by a replica if the
+// database has any
 func (m *Module) Aggregate(ctx context.Context, dbAlias, project, col string, req *model.AggregateRequest) (interface{}, error) {
 	m.RLock()
 	defer m.RUnlock()
 
-	crud, err := m.getCrudBlock(dbAlias)
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	crud, err := m.getReadBlock(ctx, dbAlias)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +254,18 @@ func (m *Module) Aggregate(ctx context.Context, dbAlias, project, col string, re
 		return nil, err
 	}
 
-	return crud.Aggregate(ctx, project, col, req)
+	// Soft deleted documents are left out of the aggregation
+	if m.isSoftDelete(dbAlias, col) {
+		req, err = excludeDeletedStage(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	result, err := crud.Aggregate(ctx, project, col, req)
+	m.trackQuery(dbAlias, col, utils.Aggregation, nil, start)
+	return result, err
 }
 
 // Batch performs a batch operation on the database
@@ -152,6 +273,9 @@ func (m *Module) Batch(ctx context.Context, dbAlias, project string, req *model.
 	m.RLock()
 	defer m.RUnlock()
 
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
 	crud, err := m.getCrudBlock(dbAlias)
 	if err != nil {
 		return err
@@ -161,19 +285,60 @@ func (m *Module) Batch(ctx context.Context, dbAlias, project string, req *model.
 		return err
 	}
 
+	for _, r := range req.Requests {
+		if err := m.checkWritable(dbAlias, r.Col); err != nil {
+			return err
+		}
+	}
+
+	for i, r := range req.Requests {
+		if r.Type == string(utils.Update) {
+			if m.isSoftDelete(dbAlias, r.Col) && r.Operation != utils.Upsert {
+				req.Requests[i].Find = excludeDeleted(r.Find)
+			}
+			req.Requests[i].ConflictKeys = m.getConflictKeys(dbAlias, r.Col, r.Operation, r.Find)
+
+			// Versioned documents are updated only if they are still at the version the request expects
+			updateReq := &model.UpdateRequest{Find: req.Requests[i].Find, Operation: r.Operation, Update: r.Update}
+			isConditional, err := m.prepareVersionedUpdate(dbAlias, r.Col, updateReq)
+			if err != nil {
+				return err
+			}
+			req.Requests[i].Find, req.Requests[i].Update, req.Requests[i].IsConditional = updateReq.Find, updateReq.Update, isConditional
+		}
+	}
+
 	// Invoke the batch intent hook
 	intent, err := m.hooks.Batch(ctx, dbAlias, req)
 	if err != nil {
 		return err
 	}
 
+	// The deletes on collections with soft delete only mark the documents as deleted
+	for i, r := range req.Requests {
+		if r.Type == string(utils.Delete) && m.isSoftDelete(dbAlias, r.Col) {
+			updateReq := generateSoftDeleteRequest(crud.GetDBType(), r.Find, r.Operation)
+			req.Requests[i] = model.AllRequest{Col: r.Col, Type: string(utils.Update), Operation: updateReq.Operation, Find: updateReq.Find, Update: updateReq.Update}
+		}
+	}
+
 	// Perform the batch operation
+	start := time.Now()
 	counts, err := crud.Batch(ctx, project, req)
+	m.trackQuery(dbAlias, batchCols(req), utils.Batch, nil, start)
+	for _, r := range req.Requests {
+		m.invalidateCache(dbAlias, r.Col)
+	}
 
 	// Invoke the metric hook if the operation was successful
-	if err == nil {
-		for i, r := range req.Requests {
-			m.metricHook(m.project, dbAlias, r.Col, counts[i], utils.OperationType(r.Operation))
+	if batchErr, ok := err.(*utils.BatchError); ok {
+		// Some databases keep the requests performed before the failing one applied
+		counts = batchErr.Counts
+	}
+	if err == nil || counts != nil {
+		for i, count := range counts {
+			r := req.Requests[i]
+			m.metricHook(m.project, dbAlias, r.Col, count, utils.OperationType(r.Operation))
 		}
 	}
 
@@ -199,6 +364,23 @@ func (m *Module) DescribeTable(ctx context.Context, dbAlias, project, col string
 	return crud.DescribeTable(ctx, project, col)
 }
 
+// SetValidator sets the json schema validating the documents of a mongo collection
+func (m *Module) SetValidator(ctx context.Context, dbAlias, project, col string, schema map[string]interface{}) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return err
+	}
+
+	if err := crud.IsClientSafe(); err != nil {
+		return err
+	}
+
+	return crud.SetValidator(ctx, project, col, schema)
+}
+
 // RawBatch performs a db operaion for schema creation
 func (m *Module) RawBatch(ctx context.Context, dbAlias string, batchedQueries []string) error {
 	m.RLock()
@@ -216,6 +398,29 @@ func (m *Module) RawBatch(ctx context.Context, dbAlias string, batchedQueries []
 	return crud.RawBatch(ctx, batchedQueries)
 }
 
+// ApplyMigration runs the queries of a schema migration and records the migration in the collection provided. The
+// record is inserted in the same transaction as the queries on databases which support transactional ddl
+func (m *Module) ApplyMigration(ctx context.Context, dbAlias, project, col string, queries []string, record map[string]interface{}) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return err
+	}
+
+	if err := crud.IsClientSafe(); err != nil {
+		return err
+	}
+
+	err = crud.ApplyMigration(ctx, project, col, queries, record)
+	m.invalidateCache(dbAlias, col)
+	return err
+}
+
 // GetCollections returns collection / tables name of specified database
 func (m *Module) GetCollections(ctx context.Context, project, dbAlias string) ([]utils.DatabaseCollections, error) {
 	m.RLock()
@@ -272,6 +477,19 @@ func (m *Module) GetConnectionState(ctx context.Context, dbAlias string) bool {
 	return crud.GetConnectionState(ctx)
 }
 
+// GetPoolStats returns the current state of the connection pool of the primary database
+func (m *Module) GetPoolStats(dbAlias string) (utils.PoolStats, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return utils.PoolStats{}, err
+	}
+
+	return crud.GetPoolStats(), nil
+}
+
 // DeleteTable drop specified table from database
 func (m *Module) DeleteTable(ctx context.Context, project, dbAlias, col string) error {
 	m.RLock()
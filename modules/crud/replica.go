@@ -0,0 +1,83 @@
+package crud
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// replicaHealthCheckInterval is the duration for which the health of a replica is cached
+const replicaHealthCheckInterval = 10 * time.Second
+
+// replicaSet holds the read replicas of a database
+type replicaSet struct {
+	replicas []*replica
+
+	// next is the counter used to pick the replicas in a round robin fashion
+	next uint32
+}
+
+// replica is a read replica of a database along with its last known health
+type replica struct {
+	lock      sync.Mutex
+	block     Crud
+	healthy   bool
+	checkedAt time.Time
+}
+
+// isHealthy checks if the replica can serve reads. The health is cached for replicaHealthCheckInterval
+func (r *replica) isHealthy(ctx context.Context) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if time.Since(r.checkedAt) < replicaHealthCheckInterval {
+		return r.healthy
+	}
+
+	r.healthy = r.block.IsClientSafe() == nil && r.block.GetConnectionState(ctx)
+	r.checkedAt = time.Now()
+	return r.healthy
+}
+
+// pick returns the next healthy replica in a round robin fashion
+func (set *replicaSet) pick(ctx context.Context) (Crud, bool) {
+	length := uint32(len(set.replicas))
+	if length == 0 {
+		return nil, false
+	}
+
+	start := atomic.AddUint32(&set.next, 1)
+	for i := uint32(0); i < length; i++ {
+		r := set.replicas[(start+i)%length]
+		if r.isHealthy(ctx) {
+			return r.block, true
+		}
+	}
+
+	return nil, false
+}
+
+// closeReplicas closes the connections to all the replicas
+func (m *Module) closeReplicas() {
+	for _, set := range m.replicas {
+		for _, r := range set.replicas {
+			if r.block != nil {
+				_ = r.block.Close()
+			}
+		}
+	}
+}
+
+// getReadBlock returns the crud block to be used for reads. Reads are served by a healthy replica if the
+// database has any. The primary database is used otherwise
+func (m *Module) getReadBlock(ctx context.Context, dbAlias string) (Crud, error) {
+	if set, p := m.replicas[strings.TrimPrefix(dbAlias, "sql-")]; p {
+		if block, ok := set.pick(ctx); ok {
+			return block, nil
+		}
+	}
+
+	return m.getCrudBlock(dbAlias)
+}
@@ -0,0 +1,136 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud/sql"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// initSQLiteDB creates a sqlite database whose only row identifies the database
+func initSQLiteDB(t *testing.T, dir, name string) string {
+	path := filepath.Join(dir, name+".db")
+	s, err := sql.Init(utils.SQLite, true, true, path, utils.PoolConfig{})
+	if err != nil {
+		t.Fatal("could not initialise sqlite:", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if err := s.RawBatch(context.Background(), []string{
+		"CREATE TABLE dbs (id varchar(50) PRIMARY KEY NOT NULL);",
+		fmt.Sprintf("INSERT INTO dbs (id) VALUES ('%s');", name),
+	}); err != nil {
+		t.Fatal("could not create table:", err)
+	}
+	return path
+}
+
+func TestModule_ReadReplicas(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-replicas")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	m := Init(true)
+	m.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := m.SetConfig("project", config.Crud{
+		"sqlite": &config.CrudStub{
+			Enabled:  true,
+			Conn:     initSQLiteDB(t, dir, "primary"),
+			Replicas: []string{initSQLiteDB(t, dir, "replica1"), initSQLiteDB(t, dir, "replica2")},
+		},
+	}); err != nil {
+		t.Fatal("could not set config:", err)
+	}
+	defer m.closeReplicas()
+
+	ctx := context.Background()
+	readFrom := func() string {
+		result, _, err := m.Read(ctx, "sqlite", "project", "dbs", &model.ReadRequest{Find: map[string]interface{}{}, Operation: utils.One})
+		if err != nil {
+			t.Fatal("could not read:", err)
+		}
+		return result.(map[string]interface{})["id"].(string)
+	}
+
+	// Reads are distributed across the replicas in a round robin fashion
+	served := map[string]int{}
+	for i := 0; i < 4; i++ {
+		served[readFrom()]++
+	}
+	if served["replica1"] != 2 || served["replica2"] != 2 {
+		t.Errorf("reads not distributed across replicas; got %v", served)
+	}
+
+	// Internal reads are always served by the primary
+	result, err := m.InternalRead(ctx, "sqlite", "project", "dbs", &model.ReadRequest{Find: map[string]interface{}{}, Operation: utils.One})
+	if err != nil {
+		t.Fatal("could not read:", err)
+	}
+	if id := result.(map[string]interface{})["id"]; id != "primary" {
+		t.Errorf("internal read served by %v; wanted primary", id)
+	}
+
+	// Unhealthy replicas are skipped
+	set := m.replicas["sqlite"]
+	set.replicas[0].healthy = false
+	for i := 0; i < 2; i++ {
+		if id := readFrom(); id != "replica2" {
+			t.Errorf("read served by %s; wanted replica2", id)
+		}
+	}
+
+	// The primary serves the reads when no replica is healthy
+	set.replicas[1].healthy = false
+	if id := readFrom(); id != "primary" {
+		t.Errorf("read served by %s; wanted primary", id)
+	}
+}
+
+func TestModule_ReadReplicasCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-replicas")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	m := Init(true)
+	m.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := m.SetConfig("project", config.Crud{
+		"sqlite": &config.CrudStub{
+			Enabled:     true,
+			Conn:        initSQLiteDB(t, dir, "primary"),
+			Replicas:    []string{initSQLiteDB(t, dir, "replica")},
+			Collections: map[string]*config.TableRule{"dbs": {Cache: &config.ReadCache{TTL: 60, MaxEntries: 10}}},
+		},
+	}); err != nil {
+		t.Fatal("could not set config:", err)
+	}
+	defer m.closeReplicas()
+
+	ctx := context.Background()
+	readFrom := func() string {
+		result, _, err := m.Read(ctx, "sqlite", "project", "dbs", &model.ReadRequest{Find: map[string]interface{}{}, Operation: utils.One})
+		if err != nil {
+			t.Fatal("could not read:", err)
+		}
+		return result.(map[string]interface{})["id"].(string)
+	}
+
+	// The reads served by a replica aren't cached since the replica may lag behind the primary
+	if id := readFrom(); id != "replica" {
+		t.Fatalf("read served by %s; wanted replica", id)
+	}
+	m.replicas["sqlite"].replicas[0].healthy = false
+	if id := readFrom(); id != "primary" {
+		t.Errorf("read served by %s; wanted primary", id)
+	}
+}
@@ -0,0 +1,128 @@
+package crud
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// maxSlowQueries is the number of the most recent slow queries which are kept in memory
+const maxSlowQueries = 100
+
+// slowQueryLog is a ring buffer of the most recent slow queries
+type slowQueryLog struct {
+	lock    sync.Mutex
+	entries []*model.SlowQuery
+	next    int
+}
+
+func newSlowQueryLog() *slowQueryLog {
+	return &slowQueryLog{entries: make([]*model.SlowQuery, 0, maxSlowQueries)}
+}
+
+func (l *slowQueryLog) add(entry *model.SlowQuery) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(l.entries) < maxSlowQueries {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % maxSlowQueries
+}
+
+// list returns the slow queries of the database, the most recent one first. The queries of all the databases are
+// returned if the db alias is empty
+func (l *slowQueryLog) list(dbAlias string) []*model.SlowQuery {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	queries := []*model.SlowQuery{}
+	for i := 1; i <= len(l.entries); i++ {
+		entry := l.entries[(l.next-i+len(l.entries))%len(l.entries)]
+		if dbAlias == "" || entry.DBAlias == dbAlias {
+			queries = append(queries, entry)
+		}
+	}
+	return queries
+}
+
+// GetSlowQueries returns the recent operations on the database which took longer than its slow query threshold, the
+// most recent one first
+func (m *Module) GetSlowQueries(dbAlias string) []*model.SlowQuery {
+	return m.slowQueries.list(strings.TrimPrefix(dbAlias, "sql-"))
+}
+
+// trackQuery logs the operation if it took longer than the slow query threshold of the database. It must be called
+// with the lock held
+func (m *Module) trackQuery(dbAlias, col string, op utils.OperationType, find map[string]interface{}, start time.Time) {
+	duration := time.Since(start)
+
+	dbAlias = strings.TrimPrefix(dbAlias, "sql-")
+	threshold, p := m.slowThresholds[dbAlias]
+	if !p {
+		threshold = utils.DefaultSlowQueryThreshold
+	}
+	if duration < threshold {
+		return
+	}
+
+	entry := &model.SlowQuery{DBAlias: dbAlias, Col: col, Operation: string(op), Find: redactFind(find), Duration: int64(duration / time.Millisecond), Time: start}
+	data, _ := json.Marshal(entry.Find)
+	log.Printf("Slow query: %s on %s (%s) took %v - find %s\n", op, col, dbAlias, duration, string(data))
+
+	m.slowQueries.add(entry)
+}
+
+// batchCols returns the comma separated collections of a batch request
+func batchCols(req *model.BatchRequest) string {
+	seen := map[string]bool{}
+	cols := []string{}
+	for _, r := range req.Requests {
+		if !seen[r.Col] {
+			seen[r.Col] = true
+			cols = append(cols, r.Col)
+		}
+	}
+	return strings.Join(cols, ",")
+}
+
+// redactFind replaces the values of the find clause with a placeholder so that no data gets logged. The fields and
+// operators are retained to identify the query
+func redactFind(find map[string]interface{}) map[string]interface{} {
+	if find == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(find))
+	for k, v := range find {
+		redacted[k] = redactValue(v)
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return redactFind(v)
+	case []interface{}:
+		// The clauses of $or and $and are find clauses themselves
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			if clause, ok := item.(map[string]interface{}); ok {
+				values[i] = redactFind(clause)
+			} else {
+				values[i] = "?"
+			}
+		}
+		return values
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,65 @@
+package crud
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestRedactFind(t *testing.T) {
+	find := map[string]interface{}{
+		"id":   "1",
+		"age":  map[string]interface{}{"$gt": 18},
+		"tags": []interface{}{"a", "b"},
+		"$or": []interface{}{
+			map[string]interface{}{"owner": "john"},
+			map[string]interface{}{"team": map[string]interface{}{"$in": []interface{}{"x"}}},
+		},
+	}
+	want := map[string]interface{}{
+		"id":   "?",
+		"age":  map[string]interface{}{"$gt": "?"},
+		"tags": []interface{}{"?", "?"},
+		"$or": []interface{}{
+			map[string]interface{}{"owner": "?"},
+			map[string]interface{}{"team": map[string]interface{}{"$in": []interface{}{"?"}}},
+		},
+	}
+	if got := redactFind(find); !reflect.DeepEqual(got, want) {
+		t.Errorf("redactFind() = %v; want %v", got, want)
+	}
+}
+
+func TestModule_TrackQuery(t *testing.T) {
+	m := Init(false)
+	m.slowThresholds["postgres"] = 50 * time.Millisecond
+
+	m.trackQuery("sql-postgres", "todos", utils.Read, nil, time.Now())
+	if got := m.GetSlowQueries("postgres"); len(got) != 0 {
+		t.Fatalf("got %d slow queries; wanted none", len(got))
+	}
+
+	// The queries are returned with the most recent one first
+	for i := 0; i < maxSlowQueries+1; i++ {
+		m.trackQuery("sql-postgres", "todos", utils.Read, map[string]interface{}{"id": i}, time.Now().Add(-time.Second))
+	}
+	m.trackQuery("mongo", "todos", utils.Delete, nil, time.Now().Add(-2*utils.DefaultSlowQueryThreshold))
+
+	got := m.GetSlowQueries("sql-postgres")
+	if len(got) != maxSlowQueries-1 {
+		t.Fatalf("got %d slow queries of postgres; wanted %d", len(got), maxSlowQueries-1)
+	}
+	if want := (&model.SlowQuery{DBAlias: "postgres", Col: "todos", Operation: "read", Find: map[string]interface{}{"id": "?"}}); got[0].DBAlias != want.DBAlias || got[0].Col != want.Col || got[0].Operation != want.Operation || !reflect.DeepEqual(got[0].Find, want.Find) {
+		t.Errorf("got slow query %+v; wanted %+v", got[0], want)
+	}
+	if got[0].Duration < 1000 {
+		t.Errorf("got duration %dms; wanted at least 1000ms", got[0].Duration)
+	}
+
+	if all := m.GetSlowQueries(""); len(all) != maxSlowQueries || all[0].DBAlias != "mongo" {
+		t.Errorf("got %d slow queries across databases starting with %s; wanted %d starting with mongo", len(all), all[0].DBAlias, maxSlowQueries)
+	}
+}
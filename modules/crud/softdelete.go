@@ -0,0 +1,181 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// isSoftDelete checks if soft delete is enabled for the collection. It must be called with the lock held
+func (m *Module) isSoftDelete(dbAlias, col string) bool {
+	return m.softDeletes[strings.TrimPrefix(dbAlias, "sql-")][col]
+}
+
+// IsSoftDelete checks if soft delete is enabled for the collection
+func (m *Module) IsSoftDelete(dbAlias, col string) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.isSoftDelete(dbAlias, col)
+}
+
+// ExcludeDeleted returns the find clause which leaves out the soft deleted documents of the collection. The find
+// clause is returned as is if soft delete is not enabled for the collection
+func (m *Module) ExcludeDeleted(dbAlias, col string, find map[string]interface{}) map[string]interface{} {
+	if !m.IsSoftDelete(dbAlias, col) {
+		return find
+	}
+	return excludeDeleted(find)
+}
+
+// excludeDeleted adds the condition which leaves out the soft deleted documents to the find clause. A condition on
+// the soft delete field provided in the find clause is left untouched
+func excludeDeleted(find map[string]interface{}) map[string]interface{} {
+	if _, p := find[utils.SoftDeleteField]; p {
+		return find
+	}
+
+	newFind := make(map[string]interface{}, len(find)+1)
+	for k, v := range find {
+		newFind[k] = v
+	}
+	newFind[utils.SoftDeleteField] = nil
+	return newFind
+}
+
+// excludeDeletedJoins marks the joins on collections with soft delete so that their soft deleted documents are left
+// out unless asked for. It must be called with the lock held
+func (m *Module) excludeDeletedJoins(dbAlias string, req *model.ReadRequest) {
+	if req.Options == nil || req.Options.WithDeleted {
+		return
+	}
+	for _, join := range req.Options.Join {
+		join.ExcludeDeleted = m.isSoftDelete(dbAlias, join.Col)
+	}
+}
+
+// excludeDeletedStage adds the stage which leaves out the soft deleted documents to the start of the pipeline
+func excludeDeletedStage(req *model.AggregateRequest) (*model.AggregateRequest, error) {
+	pipeline, ok := req.Pipeline.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid pipeline provided - wanted an array of stages")
+	}
+
+	newPipeline := make([]interface{}, 0, len(pipeline)+1)
+	newPipeline = append(newPipeline, map[string]interface{}{"$match": map[string]interface{}{utils.SoftDeleteField: nil}})
+	newPipeline = append(newPipeline, pipeline...)
+	return &model.AggregateRequest{Pipeline: newPipeline, Operation: req.Operation}, nil
+}
+
+// onlyDeleted adds the condition which selects only the soft deleted documents to the find clause
+func onlyDeleted(find map[string]interface{}) map[string]interface{} {
+	newFind := make(map[string]interface{}, len(find)+1)
+	for k, v := range find {
+		newFind[k] = v
+	}
+	newFind[utils.SoftDeleteField] = map[string]interface{}{"$ne": nil}
+	return newFind
+}
+
+// generateSoftDeleteRequest converts a delete request to the update which marks the documents as deleted. SQL
+// databases delete all the matching rows irrespective of the operation and so all of them are marked
+func generateSoftDeleteRequest(dbType utils.DBType, find map[string]interface{}, op string) *model.UpdateRequest {
+	if dbType != utils.Mongo {
+		op = utils.All
+	}
+
+	return &model.UpdateRequest{
+		Find:      excludeDeleted(find),
+		Operation: op,
+		Update:    map[string]interface{}{"$set": map[string]interface{}{utils.SoftDeleteField: time.Now().UTC()}},
+	}
+}
+
+// PurgeDeleted permanently removes the soft deleted documents which match the find clause. It returns the number
+// of documents removed
+func (m *Module) PurgeDeleted(ctx context.Context, dbAlias, project, col string, find map[string]interface{}) (int64, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	crud, err := m.getSoftDeleteBlock(dbAlias, col)
+	if err != nil {
+		return 0, err
+	}
+
+	// Invoke the delete intent hook
+	req := &model.DeleteRequest{Find: onlyDeleted(find), Operation: utils.All}
+	intent, err := m.hooks.Delete(ctx, dbAlias, col, req)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := crud.Delete(ctx, project, col, req)
+	m.invalidateCache(dbAlias, col)
+	if err == nil {
+		m.metricHook(m.project, dbAlias, col, n, utils.Delete)
+	}
+
+	// Invoke the stage hook
+	m.hooks.Stage(ctx, intent, err)
+	return n, err
+}
+
+// RestoreDeleted restores the soft deleted documents which match the find clause. It returns the number of
+// documents restored
+func (m *Module) RestoreDeleted(ctx context.Context, dbAlias, project, col string, find map[string]interface{}) (int64, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	ctx, cancel := m.withStatementTimeout(ctx, dbAlias)
+	defer cancel()
+
+	crud, err := m.getSoftDeleteBlock(dbAlias, col)
+	if err != nil {
+		return 0, err
+	}
+
+	req := &model.UpdateRequest{
+		Find:      onlyDeleted(find),
+		Operation: utils.All,
+		Update:    map[string]interface{}{"$set": map[string]interface{}{utils.SoftDeleteField: nil}},
+	}
+
+	// Invoke the update intent hook
+	intent, err := m.hooks.Update(ctx, dbAlias, col, req)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := crud.Update(ctx, project, col, req)
+	m.invalidateCache(dbAlias, col)
+	if err == nil {
+		m.metricHook(m.project, dbAlias, col, n, utils.Update)
+	}
+
+	// Invoke the stage hook
+	m.hooks.Stage(ctx, intent, err)
+	return n, err
+}
+
+func (m *Module) getSoftDeleteBlock(dbAlias, col string) (Crud, error) {
+	if !m.isSoftDelete(dbAlias, col) {
+		return nil, fmt.Errorf("soft delete is not enabled for collection (%s)", col)
+	}
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := crud.IsClientSafe(); err != nil {
+		return nil, err
+	}
+	return crud, nil
+}
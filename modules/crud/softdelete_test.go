@@ -0,0 +1,139 @@
+package crud
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud/sql"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestModule_SoftDelete(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-soft-delete")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "test.db")
+	s, err := sql.Init(utils.SQLite, true, true, path, utils.PoolConfig{})
+	if err != nil {
+		t.Fatal("could not initialise sqlite:", err)
+	}
+	if err := s.RawBatch(context.Background(), []string{
+		"CREATE TABLE todos (id varchar(50) PRIMARY KEY NOT NULL, text text, deleted_at timestamp);",
+		"INSERT INTO todos (id, text) VALUES ('1', 'first'), ('2', 'second'), ('3', 'third');",
+	}); err != nil {
+		t.Fatal("could not create table:", err)
+	}
+	_ = s.Close()
+
+	var intents []string
+	m := Init(true)
+	m.SetHooks(&model.CrudHooks{
+		Update: func(ctx context.Context, dbAlias, col string, req *model.UpdateRequest) (*model.EventIntent, error) {
+			intents = append(intents, string(utils.Update))
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Delete: func(ctx context.Context, dbAlias, col string, req *model.DeleteRequest) (*model.EventIntent, error) {
+			intents = append(intents, string(utils.Delete))
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Batch: func(ctx context.Context, dbAlias string, req *model.BatchRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Stage: func(ctx context.Context, intent *model.EventIntent, err error) {},
+	}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := m.SetConfig("project", config.Crud{
+		"sqlite": &config.CrudStub{Enabled: true, Conn: path, Collections: map[string]*config.TableRule{"todos": {SoftDelete: true}}},
+	}); err != nil {
+		t.Fatal("could not set config:", err)
+	}
+
+	ctx := context.Background()
+	count := func(withDeleted bool) int64 {
+		result, _, err := m.Read(ctx, "sqlite", "project", "todos", &model.ReadRequest{Operation: utils.Count, Options: &model.ReadOptions{WithDeleted: withDeleted}})
+		if err != nil {
+			t.Fatal("could not read:", err)
+		}
+		return result.(int64)
+	}
+
+	// Deleted documents are marked instead of being removed
+	if err := m.Delete(ctx, "sqlite", "project", "todos", &model.DeleteRequest{Find: map[string]interface{}{"id": "1"}, Operation: utils.All}); err != nil {
+		t.Fatal("could not delete:", err)
+	}
+	if err := m.Batch(ctx, "sqlite", "project", &model.BatchRequest{Requests: []model.AllRequest{
+		{Type: string(utils.Delete), Col: "todos", Find: map[string]interface{}{"id": "2"}, Operation: utils.All},
+	}}); err != nil {
+		t.Fatal("could not perform batch:", err)
+	}
+	if got := count(false); got != 1 {
+		t.Errorf("read %d documents; wanted 1", got)
+	}
+	if got := count(true); got != 3 {
+		t.Errorf("read %d documents with the deleted ones; wanted 3", got)
+	}
+
+	// Soft deleted documents are neither updated nor aggregated
+	update := &model.UpdateRequest{Find: map[string]interface{}{}, Operation: utils.All, Update: map[string]interface{}{"$set": map[string]interface{}{"text": "updated"}}}
+	if err := m.Update(ctx, "sqlite", "project", "todos", update); err != nil {
+		t.Fatal("could not update:", err)
+	}
+	docs, _, err := m.Read(ctx, "sqlite", "project", "todos", &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"text": "updated"}, Options: &model.ReadOptions{WithDeleted: true}})
+	if err != nil {
+		t.Fatal("could not read:", err)
+	}
+	if got := len(docs.([]interface{})); got != 1 {
+		t.Errorf("updated %d documents; wanted 1", got)
+	}
+	result, err := m.Aggregate(ctx, "sqlite", "project", "todos", &model.AggregateRequest{Operation: utils.All, Pipeline: []interface{}{
+		map[string]interface{}{"$group": map[string]interface{}{"_id": nil, "count": map[string]interface{}{"$sum": float64(1)}}},
+	}})
+	if err != nil {
+		t.Fatal("could not aggregate:", err)
+	}
+	if got := result.([]interface{})[0].(map[string]interface{})["count"]; got != int64(1) {
+		t.Errorf("aggregated %v documents; wanted 1", got)
+	}
+
+	// Restored documents can be read again
+	n, err := m.RestoreDeleted(ctx, "sqlite", "project", "todos", map[string]interface{}{"id": "1"})
+	if err != nil {
+		t.Fatal("could not restore:", err)
+	}
+	if n != 1 {
+		t.Errorf("restored %d documents; wanted 1", n)
+	}
+	if got := count(false); got != 2 {
+		t.Errorf("read %d documents after restoring; wanted 2", got)
+	}
+
+	// Only the soft deleted documents get purged
+	n, err = m.PurgeDeleted(ctx, "sqlite", "project", "todos", map[string]interface{}{})
+	if err != nil {
+		t.Fatal("could not purge:", err)
+	}
+	if n != 1 {
+		t.Errorf("purged %d documents; wanted 1", n)
+	}
+	if got := count(true); got != 2 {
+		t.Errorf("read %d documents after purging; wanted 2", got)
+	}
+
+	// Restoring and purging go through the hooks like any other write
+	if want := []string{string(utils.Delete), string(utils.Update), string(utils.Update), string(utils.Delete)}; !reflect.DeepEqual(intents, want) {
+		t.Errorf("invoked the hooks for %v; wanted %v", intents, want)
+	}
+
+	// Collections without soft delete cannot be purged
+	if _, err := m.PurgeDeleted(ctx, "sqlite", "project", "users", map[string]interface{}{}); err == nil {
+		t.Error("purged a collection without soft delete")
+	}
+}
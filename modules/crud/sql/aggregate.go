@@ -3,11 +3,535 @@ package sql
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/doug-martin/goqu/v8"
+	"github.com/doug-martin/goqu/v8/exp"
 
 	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// aggregateField is a field present in the output of an aggregation stage
+type aggregateField struct {
+	name string
+	exp  columnExpression
+}
+
+// The clauses of a select statement are applied in a fixed order (where, group by, having, order by, offset and
+// limit) while the stages of a pipeline are applied in the order they are provided in. Hence the stages of a pipeline
+// must follow the order of the clauses they are converted to
+const (
+	phaseMatch = iota
+	phaseGroup
+	phaseHaving
+	phaseSort
+	phaseSkip
+	phaseLimit
 )
 
-// Aggregate performs a mongo db pipeline aggregation
+// aggregateQuery holds the state of the query while the stages of the pipeline are processed
+type aggregateQuery struct {
+	query     *goqu.SelectDataset
+	regex     []string
+	grouped   bool
+	projected bool
+	phase     int // the phase of the last stage processed
+
+	// fields are the output fields in the order they need to be selected
+	fields []aggregateField
+
+	// idFields are the fields which need to be nested under `_id` in the result
+	idFields []string
+}
+
+// field returns the expression to be used for the provided field name
+func (q *aggregateQuery) field(name string) columnExpression {
+	for _, f := range q.fields {
+		if f.name == name {
+			return f.exp
+		}
+	}
+	return goqu.I(name)
+}
+
+// Aggregate performs an aggregation defined via a mongo style pipeline
 func (s *SQL) Aggregate(ctx context.Context, project, col string, req *model.AggregateRequest) (interface{}, error) {
-	return nil, errors.New("Aggregation is not supported for SQL databases")
+	sqlString, args, idFields, err := s.generateAggregateQuery(ctx, project, col, req)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := s.client.PreparexContext(ctx, sqlString)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stmt.Close() }()
+
+	rows, err := stmt.QueryxContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	rowTypes, _ := rows.ColumnTypes()
+
+	array := []interface{}{}
+	for rows.Next() {
+		mapping := make(map[string]interface{})
+		if err := rows.MapScan(mapping); err != nil {
+			return nil, err
+		}
+
+		switch s.GetDBType() {
+		case utils.MySQL, utils.Postgres, utils.SQLite:
+			mysqlTypeCheck(s.GetDBType(), rowTypes, mapping)
+		}
+
+		// Nest the group keys under _id just like mongo does
+		if len(idFields) > 0 {
+			id := make(map[string]interface{}, len(idFields))
+			for _, field := range idFields {
+				id[field] = mapping[field]
+				delete(mapping, field)
+			}
+			mapping["_id"] = id
+		}
+
+		array = append(array, mapping)
+	}
+
+	switch req.Operation {
+	case utils.One:
+		if len(array) == 0 {
+			return nil, errors.New("SQL: No response from db")
+		}
+		return array[0], nil
+
+	case utils.All:
+		return array, nil
+
+	default:
+		return nil, utils.ErrInvalidParams
+	}
+}
+
+// generateAggregateQuery makes a query for the aggregate operation. It also returns the fields which
+// need to be nested under `_id` in the result
+func (s *SQL) generateAggregateQuery(ctx context.Context, project, col string, req *model.AggregateRequest) (string, []interface{}, []string, error) {
+	stages, ok := req.Pipeline.([]interface{})
+	if !ok {
+		return "", nil, nil, errors.New("SQL: Aggregation pipeline must be an array of stages")
+	}
+
+	q := &aggregateQuery{query: goqu.Dialect(s.dialectName()).From(s.getDBName(project, col)).Prepared(true)}
+
+	for _, stage := range stages {
+		stageObj, ok := stage.(map[string]interface{})
+		if !ok || len(stageObj) != 1 {
+			return "", nil, nil, errors.New("SQL: Each aggregation stage must be an object with a single key")
+		}
+
+		for key, value := range stageObj {
+			if err := s.processAggregateStage(q, key, value); err != nil {
+				return "", nil, nil, err
+			}
+		}
+	}
+
+	if len(q.fields) > 0 {
+		selArray := make([]interface{}, len(q.fields))
+		for i, f := range q.fields {
+			selArray[i] = f.exp.As(f.name)
+		}
+		q.query = q.query.Select(selArray...)
+	}
+
+	// Generate the sql string and arguments
+	sqlString, args, err := q.query.ToSQL()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return s.formatQuery(sqlString, q.regex), args, q.idFields, nil
+}
+
+// checkPhase ensures the stage can be folded into the query built from the stages before it. Only the match stages
+// can repeat since their conditions are combined
+func (q *aggregateQuery) checkPhase(stage string, phase int) error {
+	if phase < q.phase || (phase == q.phase && phase != phaseMatch && phase != phaseHaving) {
+		return fmt.Errorf("SQL: %s stage is not supported at this position of the pipeline - the stages must be in the order $match, $group, $match, $sort, $skip and $limit", stage)
+	}
+	if q.projected && (phase == phaseMatch || phase == phaseGroup || phase == phaseHaving) {
+		return fmt.Errorf("SQL: %s stage is not supported after a $project stage", stage)
+	}
+	q.phase = phase
+	return nil
+}
+
+func (s *SQL) processAggregateStage(q *aggregateQuery, stage string, value interface{}) error {
+	switch stage {
+	case "$match":
+		find, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("SQL: $match stage must be an object")
+		}
+		if len(find) == 0 {
+			return nil
+		}
+
+		// A match after the group stage filters the groups themselves
+		if q.grouped {
+			if err := q.checkPhase(stage, phaseHaving); err != nil {
+				return err
+			}
+			e, regex, err := s.generateExpression(find, q.field)
+			if err != nil {
+				return err
+			}
+			q.query = q.query.Having(e)
+			q.regex = append(q.regex, regex...)
+			return nil
+		}
+
+		if err := q.checkPhase(stage, phaseMatch); err != nil {
+			return err
+		}
+		e, regex, err := s.generator(find)
+		if err != nil {
+			return err
+		}
+		q.query = q.query.Where(e)
+		q.regex = append(q.regex, regex...)
+		return nil
+
+	case "$group":
+		if q.grouped {
+			return errors.New("SQL: Only a single $group stage is supported")
+		}
+		group, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("SQL: $group stage must be an object")
+		}
+		if err := q.checkPhase(stage, phaseGroup); err != nil {
+			return err
+		}
+		return q.group(group)
+
+	case "$sort":
+		if err := q.checkPhase(stage, phaseSort); err != nil {
+			return err
+		}
+		orderBys, err := generateAggregateSort(value)
+		if err != nil {
+			return err
+		}
+		q.query = q.query.Order(orderBys...)
+		return nil
+
+	case "$limit":
+		if err := q.checkPhase(stage, phaseLimit); err != nil {
+			return err
+		}
+		limit, err := aggregateNumber(stage, value)
+		if err != nil {
+			return err
+		}
+		q.query = q.query.Limit(limit)
+		return nil
+
+	case "$skip":
+		if err := q.checkPhase(stage, phaseSkip); err != nil {
+			return err
+		}
+		skip, err := aggregateNumber(stage, value)
+		if err != nil {
+			return err
+		}
+		q.query = q.query.Offset(skip)
+		return nil
+
+	case "$project":
+		project, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("SQL: $project stage must be an object")
+		}
+		// Projecting doesn't change the rows or their order, so it can be applied at any position
+		q.projected = true
+		return q.project(project)
+
+	default:
+		return fmt.Errorf("SQL: Aggregation stage (%s) is not supported", stage)
+	}
+}
+
+func (q *aggregateQuery) group(group map[string]interface{}) error {
+	id, ok := group["_id"]
+	if !ok {
+		return errors.New("SQL: $group stage must have an _id field")
+	}
+
+	fields := []aggregateField{}
+	groupBy := []interface{}{}
+
+	switch v := id.(type) {
+	case nil:
+		// The entire table is a single group
+
+	case string:
+		column, err := fieldReference(v)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, aggregateField{name: "_id", exp: q.field(column)})
+		groupBy = append(groupBy, q.field(column))
+
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			ref, ok := v[key].(string)
+			if !ok {
+				return fmt.Errorf("SQL: Invalid value provided for group key (%s)", key)
+			}
+			column, err := fieldReference(ref)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, aggregateField{name: key, exp: q.field(column)})
+			groupBy = append(groupBy, q.field(column))
+			q.idFields = append(q.idFields, key)
+		}
+
+	default:
+		return errors.New("SQL: Invalid _id provided in $group stage")
+	}
+
+	for _, key := range sortedKeys(group) {
+		if key == "_id" {
+			continue
+		}
+
+		accumulator, ok := group[key].(map[string]interface{})
+		if !ok || len(accumulator) != 1 {
+			return fmt.Errorf("SQL: Field (%s) in $group stage must be an object with a single accumulator", key)
+		}
+
+		for op, arg := range accumulator {
+			e, err := q.accumulate(op, arg)
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if f.name == key {
+					return fmt.Errorf("SQL: Field (%s) is used more than once in $group stage", key)
+				}
+			}
+			fields = append(fields, aggregateField{name: key, exp: e})
+		}
+	}
+
+	if len(groupBy) > 0 {
+		q.query = q.query.GroupBy(groupBy...)
+	}
+	q.fields = fields
+	q.grouped = true
+	return nil
+}
+
+func (q *aggregateQuery) accumulate(op string, arg interface{}) (columnExpression, error) {
+	switch op {
+	case "$count":
+		return goqu.COUNT(goqu.Star()), nil
+
+	case "$sum":
+		// A numeric argument sums up a constant over each row of the group
+		if n, ok := toFloat(arg); ok {
+			if n == 1 {
+				return goqu.COUNT(goqu.Star()), nil
+			}
+			return goqu.SUM(goqu.L(fmt.Sprintf("%v", n))), nil
+		}
+		column, err := accumulatorField(op, arg)
+		if err != nil {
+			return nil, err
+		}
+		return goqu.SUM(q.field(column)), nil
+
+	case "$avg":
+		column, err := accumulatorField(op, arg)
+		if err != nil {
+			return nil, err
+		}
+		return goqu.AVG(q.field(column)), nil
+
+	case "$min":
+		column, err := accumulatorField(op, arg)
+		if err != nil {
+			return nil, err
+		}
+		return goqu.MIN(q.field(column)), nil
+
+	case "$max":
+		column, err := accumulatorField(op, arg)
+		if err != nil {
+			return nil, err
+		}
+		return goqu.MAX(q.field(column)), nil
+
+	default:
+		return nil, fmt.Errorf("SQL: Accumulator (%s) is not supported", op)
+	}
+}
+
+func (q *aggregateQuery) project(project map[string]interface{}) error {
+	fields := []aggregateField{}
+	idFields := []string{}
+	excludeID := false
+
+	for _, key := range sortedKeys(project) {
+		switch v := project[key].(type) {
+		case string:
+			column, err := fieldReference(v)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, aggregateField{name: key, exp: q.field(column)})
+
+		default:
+			if !isTruthy(v) {
+				if key != "_id" {
+					return errors.New("SQL: Only the _id field can be excluded in $project stage")
+				}
+				excludeID = true
+				continue
+			}
+
+			if key == "_id" {
+				continue
+			}
+			fields = append(fields, aggregateField{name: key, exp: q.field(key)})
+		}
+	}
+
+	// Like mongo, the _id field is included unless explicitly excluded
+	if q.grouped && !excludeID {
+		var id []aggregateField
+		for _, f := range q.fields {
+			if f.name == "_id" {
+				id = append(id, f)
+				continue
+			}
+			for _, idField := range q.idFields {
+				if f.name == idField {
+					id = append(id, f)
+					idFields = append(idFields, idField)
+				}
+			}
+		}
+		fields = append(id, fields...)
+	}
+
+	q.fields = fields
+	q.idFields = idFields
+	return nil
+}
+
+func generateAggregateSort(value interface{}) ([]exp.OrderedExpression, error) {
+	var orderBys []exp.OrderedExpression
+
+	switch v := value.(type) {
+	case []interface{}:
+		// The sort array used in read options ("-field" for descending order)
+		for _, item := range v {
+			field, ok := item.(string)
+			if !ok {
+				return nil, errors.New("SQL: $sort stage must contain field names")
+			}
+			if strings.HasPrefix(field, "-") {
+				orderBys = append(orderBys, goqu.I(strings.TrimPrefix(field, "-")).Desc())
+			} else {
+				orderBys = append(orderBys, goqu.I(field).Asc())
+			}
+		}
+
+	case map[string]interface{}:
+		// The mongo style sort object ({"field": -1} for descending order). The order of the keys of an object is
+		// lost while decoding it, so the array form must be used to sort by more than one field
+		if len(v) > 1 {
+			return nil, errors.New(`SQL: $sort stage object must have a single field - use the array form (e.g. ["-a", "b"]) to sort by more than one field`)
+		}
+		for field := range v {
+			switch order, _ := toFloat(v[field]); order {
+			case 1:
+				orderBys = append(orderBys, goqu.I(field).Asc())
+			case -1:
+				orderBys = append(orderBys, goqu.I(field).Desc())
+			default:
+				return nil, fmt.Errorf("SQL: Invalid sort order provided for field (%s)", field)
+			}
+		}
+
+	default:
+		return nil, errors.New("SQL: $sort stage must be an object or an array")
+	}
+
+	return orderBys, nil
+}
+
+// fieldReference returns the field name of a field path like `$field`
+func fieldReference(ref string) (string, error) {
+	if !strings.HasPrefix(ref, "$") || len(ref) == 1 {
+		return "", fmt.Errorf("SQL: Invalid field reference (%s) - field references must start with $", ref)
+	}
+	return strings.TrimPrefix(ref, "$"), nil
+}
+
+func accumulatorField(op string, arg interface{}) (string, error) {
+	ref, ok := arg.(string)
+	if !ok {
+		return "", fmt.Errorf("SQL: Accumulator (%s) expects a field reference", op)
+	}
+	return fieldReference(ref)
+}
+
+func aggregateNumber(stage string, value interface{}) (uint, error) {
+	n, ok := toFloat(value)
+	if !ok || n < 0 || n != float64(uint(n)) {
+		return 0, fmt.Errorf("SQL: %s stage expects a non negative integer", stage)
+	}
+	return uint(n), nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func isTruthy(value interface{}) bool {
+	if b, ok := value.(bool); ok {
+		return b
+	}
+	n, ok := toFloat(value)
+	return ok && n != 0
+}
+
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,174 @@
+package sql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateAggregateQuery(t *testing.T) {
+	var tests = []struct {
+		name     string
+		dbType   string
+		pipeline []interface{}
+		want     string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:   "group with having sort and limit",
+			dbType: string(utils.Postgres),
+			pipeline: []interface{}{
+				map[string]interface{}{"$match": map[string]interface{}{"status": "done"}},
+				map[string]interface{}{"$group": map[string]interface{}{"_id": "$user", "total": map[string]interface{}{"$sum": "$amount"}, "count": map[string]interface{}{"$sum": 1}}},
+				map[string]interface{}{"$match": map[string]interface{}{"total": map[string]interface{}{"$gt": 10}}},
+				map[string]interface{}{"$sort": map[string]interface{}{"total": -1}},
+				map[string]interface{}{"$skip": float64(5)},
+				map[string]interface{}{"$limit": float64(10)},
+			},
+			want:     "SELECT user AS _id, COUNT(*) AS count, SUM(amount) AS total FROM proj.orders WHERE (status = $1) GROUP BY user HAVING (SUM(amount) > $2) ORDER BY total DESC LIMIT $3 OFFSET $4",
+			wantArgs: []interface{}{"done", int64(10), int64(10), int64(5)},
+		},
+		{
+			name:   "group on multiple fields",
+			dbType: string(utils.MySQL),
+			pipeline: []interface{}{
+				map[string]interface{}{"$group": map[string]interface{}{"_id": map[string]interface{}{"city": "$city", "age": "$age"}, "avg": map[string]interface{}{"$avg": "$score"}, "min": map[string]interface{}{"$min": "$score"}, "max": map[string]interface{}{"$max": "$score"}}},
+				map[string]interface{}{"$sort": []interface{}{"-avg"}},
+			},
+			want: "SELECT age AS age, city AS city, AVG(score) AS avg, MAX(score) AS max, MIN(score) AS min FROM proj.orders GROUP BY age, city ORDER BY avg DESC",
+		},
+		{
+			name:   "project renames fields",
+			dbType: string(utils.Postgres),
+			pipeline: []interface{}{
+				map[string]interface{}{"$group": map[string]interface{}{"_id": nil, "n": map[string]interface{}{"$count": map[string]interface{}{}}}},
+				map[string]interface{}{"$project": map[string]interface{}{"_id": 0, "total": "$n"}},
+			},
+			want: "SELECT COUNT(*) AS total FROM proj.orders",
+		},
+		{
+			name:   "sql server placeholders",
+			dbType: string(utils.SqlServer),
+			pipeline: []interface{}{
+				map[string]interface{}{"$match": map[string]interface{}{"status": "done"}},
+				map[string]interface{}{"$group": map[string]interface{}{"_id": "$user", "total": map[string]interface{}{"$max": "$amount"}}},
+			},
+			want:     "SELECT user AS _id, MAX(amount) AS total FROM proj.orders WHERE (status = @p1) GROUP BY user",
+			wantArgs: []interface{}{"done"},
+		},
+		{
+			name:     "unsupported stage",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$unwind": "$items"}},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported accumulator",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$group": map[string]interface{}{"_id": nil, "f": map[string]interface{}{"$first": "$amount"}}}},
+			wantErr:  true,
+		},
+		{
+			name:     "sort object with more than one field",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$sort": map[string]interface{}{"b": float64(1), "a": float64(-1)}}},
+			wantErr:  true,
+		},
+		{
+			name:     "match after limit",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$limit": float64(10)}, map[string]interface{}{"$match": map[string]interface{}{"status": "done"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "skip after limit",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$limit": float64(10)}, map[string]interface{}{"$skip": float64(5)}},
+			wantErr:  true,
+		},
+		{
+			name:     "group after sort",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$sort": []interface{}{"user"}}, map[string]interface{}{"$group": map[string]interface{}{"_id": "$user"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "match after project",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$project": map[string]interface{}{"total": "$amount"}}, map[string]interface{}{"$match": map[string]interface{}{"total": 1}}},
+			wantErr:  true,
+		},
+		{
+			name:   "consecutive matches",
+			dbType: string(utils.Postgres),
+			pipeline: []interface{}{
+				map[string]interface{}{"$match": map[string]interface{}{"status": "done"}},
+				map[string]interface{}{"$match": map[string]interface{}{"user": "1"}},
+				map[string]interface{}{"$project": map[string]interface{}{"user": 1}},
+				map[string]interface{}{"$limit": float64(10)},
+			},
+			want: "SELECT user AS user FROM proj.orders WHERE ((status = $1) AND (user = $2)) LIMIT $3",
+		},
+		{
+			name:     "multiple group stages",
+			dbType:   string(utils.Postgres),
+			pipeline: []interface{}{map[string]interface{}{"$group": map[string]interface{}{"_id": nil}}, map[string]interface{}{"$group": map[string]interface{}{"_id": nil}}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SQL{dbType: tt.dbType}
+			got, args, _, err := s.generateAggregateQuery(context.Background(), "proj", "orders", &model.AggregateRequest{Pipeline: tt.pipeline, Operation: utils.All})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateAggregateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("generateAggregateQuery() got = %v, want %v", got, tt.want)
+			}
+			if len(tt.wantArgs) > 0 && !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("generateAggregateQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSQLite_Aggregate(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	docs := []interface{}{
+		map[string]interface{}{"id": "1", "text": "first", "priority": 1},
+		map[string]interface{}{"id": "2", "text": "second", "priority": 1},
+		map[string]interface{}{"id": "3", "text": "third", "priority": 3},
+	}
+	if _, err := s.Create(ctx, "test", "todos", &model.CreateRequest{Operation: utils.All, Document: docs}); err != nil {
+		t.Fatal("Create() error:", err)
+	}
+
+	result, err := s.Aggregate(ctx, "test", "todos", &model.AggregateRequest{Operation: utils.All, Pipeline: []interface{}{
+		map[string]interface{}{"$group": map[string]interface{}{"_id": map[string]interface{}{"priority": "$priority"}, "count": map[string]interface{}{"$sum": float64(1)}}},
+		map[string]interface{}{"$sort": map[string]interface{}{"count": float64(-1)}},
+	}})
+	if err != nil {
+		t.Fatal("Aggregate() error:", err)
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"_id": map[string]interface{}{"priority": int64(1)}, "count": int64(2)},
+		map[string]interface{}{"_id": map[string]interface{}{"priority": int64(3)}, "count": int64(1)},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Aggregate() = %v; want %v", result, want)
+	}
+}
@@ -2,57 +2,107 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
-// Batch performs the provided operations in a single Batch
-func (s *SQL) Batch(ctx context.Context, project string, txRequest *model.BatchRequest) ([]int64, error) {
+// isolationLevels maps the isolation levels accepted in a batch request to the ones understood by database/sql
+var isolationLevels = map[string]sql.IsolationLevel{
+	"":                 sql.LevelDefault,
+	"read-uncommitted": sql.LevelReadUncommitted,
+	"read-committed":   sql.LevelReadCommitted,
+	"write-committed":  sql.LevelWriteCommitted,
+	"repeatable-read":  sql.LevelRepeatableRead,
+	"snapshot":         sql.LevelSnapshot,
+	"serializable":     sql.LevelSerializable,
+	"linearizable":     sql.LevelLinearizable,
+}
 
-	// Create an array to hold the counts
-	counts := make([]int64, len(txRequest.Requests))
+// Batch performs the provided operations in a single Batch. Either all the operations get applied or none
+func (s *SQL) Batch(ctx context.Context, project string, txRequest *model.BatchRequest) ([]int64, error) {
+	opts, err := generateTxOptions(txRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a transaction object
-	tx, err := s.client.BeginTxx(ctx, nil) //TODO - Write *sqlx.TxOption instead of nil
+	tx, err := s.client.BeginTxx(ctx, opts)
 	if err != nil {
-		fmt.Println("Error in initiating Batch")
-		return counts, err
+		return nil, fmt.Errorf("could not begin batch transaction: %v", err)
 	}
 
+	counts, err := s.batch(ctx, project, txRequest, tx)
+	if err != nil {
+		// Roll back the changes made by the earlier requests of the batch
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (s *SQL) batch(ctx context.Context, project string, txRequest *model.BatchRequest, tx *sqlx.Tx) ([]int64, error) {
+	// Create an array to hold the counts
+	counts := make([]int64, len(txRequest.Requests))
+
 	for i, req := range txRequest.Requests {
-		switch req.Type {
-		case string(utils.Create):
-			sqlQuery, args, err := s.generateCreateQuery(ctx, project, req.Col, &model.CreateRequest{Document: req.Document, Operation: req.Operation})
-			if err != nil {
-				return counts, err
-			}
-			res, err := doExecContext(ctx, sqlQuery, args, tx)
-			if err != nil {
-				return counts, err
-			}
-			counts[i], _ = res.RowsAffected()
-
-		case string(utils.Delete):
-			sqlQuery, args, err := s.generateDeleteQuery(ctx, project, req.Col, &model.DeleteRequest{Find: req.Find, Operation: req.Operation})
-			if err != nil {
-				return counts, err
-			}
-			res, err := doExecContext(ctx, sqlQuery, args, tx)
-			if err != nil {
-				return counts, err
-			}
-			counts[i], _ = res.RowsAffected()
-
-		case string(utils.Update):
-			n, err := s.update(ctx, project, req.Col, &model.UpdateRequest{Find: req.Find, Operation: req.Operation, Update: req.Update}, tx)
-			if err != nil {
-				return counts, err
-			}
-			counts[i] = n
+		n, err := s.batchRequest(ctx, project, req, tx)
+		if err != nil {
+			return nil, &utils.BatchError{Index: i, Err: err}
+		}
+		counts[i] = n
+	}
+	return counts, nil
+}
+
+func (s *SQL) batchRequest(ctx context.Context, project string, req model.AllRequest, tx *sqlx.Tx) (int64, error) {
+	switch req.Type {
+	case string(utils.Create):
+		sqlQuery, args, err := s.generateCreateQuery(ctx, project, req.Col, &model.CreateRequest{Document: req.Document, Operation: req.Operation})
+		if err != nil {
+			return 0, err
+		}
+		res, err := doExecContext(ctx, sqlQuery, args, tx)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
 
+	case string(utils.Delete):
+		sqlQuery, args, err := s.generateDeleteQuery(ctx, project, req.Col, &model.DeleteRequest{Find: req.Find, Operation: req.Operation})
+		if err != nil {
+			return 0, err
 		}
+		res, err := doExecContext(ctx, sqlQuery, args, tx)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
+
+	case string(utils.Update):
+		n, err := s.update(ctx, project, req.Col, &model.UpdateRequest{Find: req.Find, Operation: req.Operation, Update: req.Update, ConflictKeys: req.ConflictKeys}, tx)
+		if err == nil && req.IsConditional && n == 0 {
+			return 0, utils.ErrVersionConflict
+		}
+		return n, err
+
+	default:
+		return 0, utils.ErrInvalidParams
+	}
+}
+
+func generateTxOptions(txRequest *model.BatchRequest) (*sql.TxOptions, error) {
+	level, ok := isolationLevels[txRequest.IsolationLevel]
+	if !ok {
+		return nil, fmt.Errorf("Invalid isolation level (%s) provided", txRequest.IsolationLevel)
 	}
-	return counts, tx.Commit() // commit the Batch
+	return &sql.TxOptions{Isolation: level, ReadOnly: txRequest.ReadOnly}, nil
 }
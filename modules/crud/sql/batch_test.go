@@ -0,0 +1,35 @@
+package sql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+)
+
+func TestGenerateTxOptions(t *testing.T) {
+	var tests = []struct {
+		name    string
+		req     model.BatchRequest
+		want    *sql.TxOptions
+		wantErr bool
+	}{
+		{name: "default options", req: model.BatchRequest{}, want: &sql.TxOptions{Isolation: sql.LevelDefault}},
+		{name: "serializable read only", req: model.BatchRequest{IsolationLevel: "serializable", ReadOnly: true}, want: &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}},
+		{name: "repeatable read", req: model.BatchRequest{IsolationLevel: "repeatable-read"}, want: &sql.TxOptions{Isolation: sql.LevelRepeatableRead}},
+		{name: "invalid isolation level", req: model.BatchRequest{IsolationLevel: "chaos"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateTxOptions(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateTxOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("generateTxOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
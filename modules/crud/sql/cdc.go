@@ -0,0 +1,201 @@
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// cdcPollInterval is the interval at which the replication slot is polled for changes
+const cdcPollInterval = time.Second
+
+// cdcPlugin is the output plugin used for logical decoding. The changes are read with the sql functions of logical
+// decoding, which only work with plugins having a textual output. Hence pgoutput isn't supported
+const cdcPlugin = "wal2json"
+
+// wal2jsonChange is a change emitted by the wal2json output plugin (format version 2)
+type wal2jsonChange struct {
+	Action   string           `json:"action"`
+	Schema   string           `json:"schema"`
+	Table    string           `json:"table"`
+	Columns  []wal2jsonColumn `json:"columns"`
+	Identity []wal2jsonColumn `json:"identity"`
+	PK       []wal2jsonColumn `json:"pk"`
+
+	// LSN is the position of the change in the write ahead log
+	LSN string `json:"-"`
+}
+
+type wal2jsonColumn struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// WatchChanges streams the changes made to the tables of the project using logical decoding. The changes are consumed
+// from a replication slot using the wal2json output plugin, which gets created if it doesn't exist. The slot is only
+// advanced once the changes of a transaction have been processed, so no change is lost if space cloud goes down. It
+// blocks till the context gets cancelled or the polling fails
+func (s *SQL) WatchChanges(ctx context.Context, project, slot string, onChange func(*model.DatabaseChange) error) error {
+	if s.GetDBType() != utils.Postgres {
+		return fmt.Errorf("change data capture is not supported for %s", s.dbType)
+	}
+	if slot == "" {
+		return fmt.Errorf("replication slot is required for change data capture on %s", s.dbType)
+	}
+
+	if err := s.createSlotIfNotExists(ctx, slot); err != nil {
+		return err
+	}
+
+	schema := project
+	if s.removeProjectScope {
+		schema = "public"
+	}
+
+	ticker := time.NewTicker(cdcPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.pollChanges(ctx, slot, schema, onChange); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SQL) createSlotIfNotExists(ctx context.Context, slot string) error {
+	var plugins []string
+	if err := s.client.SelectContext(ctx, &plugins, "SELECT plugin FROM pg_replication_slots WHERE slot_name = $1", slot); err != nil {
+		return err
+	}
+	if len(plugins) > 0 {
+		if plugins[0] != cdcPlugin {
+			return fmt.Errorf("replication slot (%s) uses the %s plugin - only %s is supported for change data capture", slot, plugins[0], cdcPlugin)
+		}
+		return nil
+	}
+
+	_, err := s.client.ExecContext(ctx, "SELECT pg_create_logical_replication_slot($1, $2)", slot, cdcPlugin)
+	return err
+}
+
+// pollChanges processes the changes available in the replication slot. The changes are peeked so that they stay in
+// the slot till they have been processed
+func (s *SQL) pollChanges(ctx context.Context, slot, schema string, onChange func(*model.DatabaseChange) error) error {
+	changes, err := s.peekChanges(ctx, slot)
+	if err != nil {
+		return err
+	}
+
+	// The rows have been closed by now, so the slot can be advanced over the same connection
+	return processChanges(changes, schema, onChange, func(lsn string) error {
+		_, err := s.client.ExecContext(ctx, "SELECT pg_replication_slot_advance($1, $2::pg_lsn)", slot, lsn)
+		return err
+	})
+}
+
+// peekChanges reads all the changes available in the replication slot without consuming them
+func (s *SQL) peekChanges(ctx context.Context, slot string) ([]*wal2jsonChange, error) {
+	rows, err := s.client.QueryxContext(ctx, "SELECT lsn::text, data FROM pg_logical_slot_peek_changes($1, NULL, NULL, 'format-version', '2', 'include-pk', '1')", slot)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	changes := []*wal2jsonChange{}
+	for rows.Next() {
+		var lsn, data string
+		if err := rows.Scan(&lsn, &data); err != nil {
+			return nil, err
+		}
+
+		event := &wal2jsonChange{LSN: lsn}
+		if err := json.Unmarshal([]byte(data), event); err != nil {
+			return nil, err
+		}
+		changes = append(changes, event)
+	}
+
+	return changes, rows.Err()
+}
+
+// processChanges hands the changes of the schema over to onChange. The slot is advanced past a transaction once all its
+// changes have been processed. Advancing it to a change within the transaction would get the whole transaction decoded
+// again. Processing stops at the first change which fails, so that it gets decoded again on the next poll
+func processChanges(changes []*wal2jsonChange, schema string, onChange func(*model.DatabaseChange) error, advance func(lsn string) error) error {
+	for _, event := range changes {
+		if event.Action == "C" {
+			if err := advance(event.LSN); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if event.Schema != schema {
+			continue
+		}
+
+		if change := generateDatabaseChange(event); change != nil {
+			if err := onChange(change); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func generateDatabaseChange(event *wal2jsonChange) *model.DatabaseChange {
+	change := &model.DatabaseChange{Col: event.Table}
+
+	switch event.Action {
+	case "I":
+		change.Type = utils.EventDBCreate
+		change.Doc = columnsToDoc(event.Columns)
+		change.Find = primaryKeyFind(event.PK, change.Doc)
+
+	case "U":
+		change.Type = utils.EventDBUpdate
+		change.Doc = columnsToDoc(event.Columns)
+		change.Find = primaryKeyFind(event.PK, change.Doc)
+
+		// The identity holds the old values of the key in case it got updated
+		if len(event.Identity) > 0 {
+			change.Find = columnsToDoc(event.Identity)
+		}
+
+	case "D":
+		change.Type = utils.EventDBDelete
+		change.Find = columnsToDoc(event.Identity)
+
+	default:
+		// Transaction boundaries, truncates and messages don't correspond to a document
+		return nil
+	}
+
+	return change
+}
+
+func columnsToDoc(columns []wal2jsonColumn) map[string]interface{} {
+	doc := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		doc[column.Name] = column.Value
+	}
+	return doc
+}
+
+func primaryKeyFind(pk []wal2jsonColumn, doc map[string]interface{}) map[string]interface{} {
+	find := make(map[string]interface{}, len(pk))
+	for _, column := range pk {
+		find[column.Name] = doc[column.Name]
+	}
+	return find
+}
@@ -0,0 +1,106 @@
+package sql
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateDatabaseChange(t *testing.T) {
+	var tests = []struct {
+		name string
+		data string
+		want *model.DatabaseChange
+	}{
+		{
+			name: "insert",
+			data: `{"action":"I","schema":"project","table":"todos","columns":[{"name":"id","type":"text","value":"1"},{"name":"text","type":"text","value":"first"}],"pk":[{"name":"id","type":"text"}]}`,
+			want: &model.DatabaseChange{Type: utils.EventDBCreate, Col: "todos", Doc: map[string]interface{}{"id": "1", "text": "first"}, Find: map[string]interface{}{"id": "1"}},
+		},
+		{
+			name: "update",
+			data: `{"action":"U","schema":"project","table":"todos","columns":[{"name":"id","type":"text","value":"1"},{"name":"text","type":"text","value":"second"}],"pk":[{"name":"id","type":"text"}]}`,
+			want: &model.DatabaseChange{Type: utils.EventDBUpdate, Col: "todos", Doc: map[string]interface{}{"id": "1", "text": "second"}, Find: map[string]interface{}{"id": "1"}},
+		},
+		{
+			name: "update of the primary key",
+			data: `{"action":"U","schema":"project","table":"todos","columns":[{"name":"id","type":"text","value":"2"},{"name":"text","type":"text","value":"first"}],"identity":[{"name":"id","type":"text","value":"1"}],"pk":[{"name":"id","type":"text"}]}`,
+			want: &model.DatabaseChange{Type: utils.EventDBUpdate, Col: "todos", Doc: map[string]interface{}{"id": "2", "text": "first"}, Find: map[string]interface{}{"id": "1"}},
+		},
+		{
+			name: "delete",
+			data: `{"action":"D","schema":"project","table":"todos","identity":[{"name":"id","type":"text","value":"1"}],"pk":[{"name":"id","type":"text"}]}`,
+			want: &model.DatabaseChange{Type: utils.EventDBDelete, Col: "todos", Find: map[string]interface{}{"id": "1"}},
+		},
+		{
+			name: "transaction boundary",
+			data: `{"action":"B"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := new(wal2jsonChange)
+			if err := json.Unmarshal([]byte(test.data), event); err != nil {
+				t.Fatal("could not unmarshal change:", err)
+			}
+
+			if got := generateDatabaseChange(event); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("generateDatabaseChange() = %v; want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProcessChanges(t *testing.T) {
+	changes := []*wal2jsonChange{
+		{Action: "B", LSN: "0/1"},
+		{Action: "I", Schema: "project", Table: "todos", Columns: []wal2jsonColumn{{Name: "id", Value: "1"}}, LSN: "0/2"},
+		{Action: "C", LSN: "0/3"},
+		{Action: "B", LSN: "0/4"},
+		{Action: "I", Schema: "other", Table: "todos", Columns: []wal2jsonColumn{{Name: "id", Value: "2"}}, LSN: "0/5"},
+		{Action: "I", Schema: "project", Table: "notes", Columns: []wal2jsonColumn{{Name: "id", Value: "3"}}, LSN: "0/6"},
+		{Action: "C", LSN: "0/7"},
+	}
+
+	var advanced []string
+	advance := func(lsn string) error {
+		advanced = append(advanced, lsn)
+		return nil
+	}
+
+	// The slot is advanced past every transaction once its changes are processed
+	var cols []string
+	err := processChanges(changes, "project", func(change *model.DatabaseChange) error {
+		cols = append(cols, change.Col)
+		return nil
+	}, advance)
+	if err != nil {
+		t.Fatal("processChanges() error:", err)
+	}
+	if !reflect.DeepEqual(cols, []string{"todos", "notes"}) {
+		t.Errorf("processChanges() processed the changes of %v; want [todos notes]", cols)
+	}
+	if !reflect.DeepEqual(advanced, []string{"0/3", "0/7"}) {
+		t.Errorf("processChanges() advanced the slot to %v; want [0/3 0/7]", advanced)
+	}
+
+	// The slot is not advanced past a transaction with a change which failed
+	advanced = nil
+	err = processChanges(changes, "project", func(change *model.DatabaseChange) error {
+		if change.Col == "notes" {
+			return errors.New("could not process change")
+		}
+		return nil
+	}, advance)
+	if err == nil {
+		t.Error("processChanges() succeeded even though a change failed")
+	}
+	if !reflect.DeepEqual(advanced, []string{"0/3"}) {
+		t.Errorf("processChanges() advanced the slot to %v; want [0/3]", advanced)
+	}
+}
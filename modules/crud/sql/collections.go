@@ -9,8 +9,12 @@ import (
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
-// GetCollections returns collection / tables name of specified database
+// GetCollections returns collection / tables name of specified database. Views are returned as well
 func (s *SQL) GetCollections(ctx context.Context, project string) ([]utils.DatabaseCollections, error) {
+	if s.dbType == string(utils.SQLite) {
+		return s.getSQLiteCollections(ctx)
+	}
+
 	dialect := goqu.Dialect(s.dbType)
 	query := dialect.From("information_schema.tables").Prepared(true).Select("table_name").Where(goqu.Ex{"table_schema": project})
 
@@ -41,5 +45,56 @@ func (s *SQL) GetCollections(ctx context.Context, project string) ([]utils.Datab
 		result = append(result, utils.DatabaseCollections{TableName: tableName})
 	}
 
+	// Materialized views aren't listed in the information schema of postgres
+	if s.dbType == string(utils.Postgres) {
+		views, err := s.getMaterializedViews(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, views...)
+	}
+
+	return result, nil
+}
+
+func (s *SQL) getMaterializedViews(ctx context.Context, project string) ([]utils.DatabaseCollections, error) {
+	rows, err := s.client.QueryxContext(ctx, "SELECT matviewname FROM pg_matviews WHERE schemaname = $1", project)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make([]utils.DatabaseCollections, 0)
+	for rows.Next() {
+		var viewName string
+
+		if err := rows.Scan(&viewName); err != nil {
+			return nil, err
+		}
+
+		result = append(result, utils.DatabaseCollections{TableName: viewName})
+	}
+
+	return result, nil
+}
+
+func (s *SQL) getSQLiteCollections(ctx context.Context) ([]utils.DatabaseCollections, error) {
+	rows, err := s.client.QueryxContext(ctx, "SELECT name FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make([]utils.DatabaseCollections, 0)
+	for rows.Next() {
+		var tableName string
+
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, err
+		}
+
+		result = append(result, utils.DatabaseCollections{TableName: tableName})
+	}
+
 	return result, nil
 }
@@ -2,7 +2,6 @@ package sql
 
 import (
 	"context"
-	"strings"
 
 	"github.com/doug-martin/goqu/v8"
 
@@ -32,12 +31,7 @@ func (s *SQL) Create(ctx context.Context, project, col string, req *model.Create
 // generateCreateQuery makes query for create operation
 func (s *SQL) generateCreateQuery(ctx context.Context, project, col string, req *model.CreateRequest) (string, []interface{}, error) {
 	// Generate a prepared query builder
-	dbType := s.dbType
-	if dbType == string(utils.SqlServer) {
-		dbType = string(utils.Postgres)
-	}
-
-	dialect := goqu.Dialect(dbType)
+	dialect := goqu.Dialect(s.dialectName())
 	query := dialect.From(s.getDBName(project, col)).Prepared(true)
 
 	var insert []interface{}
@@ -69,7 +63,7 @@ func (s *SQL) generateCreateQuery(ctx context.Context, project, col string, req
 		return "", nil, err
 	}
 
-	sqlQuery = strings.Replace(sqlQuery, "\"", "", -1)
+	sqlQuery = removeQuotes(sqlQuery)
 	if s.dbType == string(utils.SqlServer) {
 		sqlQuery = s.generateQuerySQLServer(sqlQuery)
 	}
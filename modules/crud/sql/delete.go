@@ -2,7 +2,6 @@ package sql
 
 import (
 	"context"
-	"strings"
 
 	"github.com/doug-martin/goqu/v8"
 
@@ -33,12 +32,7 @@ func (s *SQL) Delete(ctx context.Context, project, col string, req *model.Delete
 func (s *SQL) generateDeleteQuery(ctx context.Context, project, col string, req *model.DeleteRequest) (string, []interface{}, error) {
 	// Generate a prepared query builder
 
-	dbType := s.dbType
-	if dbType == string(utils.SqlServer) {
-		dbType = string(utils.Postgres)
-	}
-
-	dialect := goqu.Dialect(dbType)
+	dialect := goqu.Dialect(s.dialectName())
 	query := dialect.From(s.getDBName(project, col)).Prepared(true)
 
 	if req.Find != nil {
@@ -55,7 +49,7 @@ func (s *SQL) generateDeleteQuery(ctx context.Context, project, col string, req
 	if err != nil {
 		return "", nil, err
 	}
-	sqlString = strings.Replace(sqlString, "\"", "", -1)
+	sqlString = removeQuotes(sqlString)
 
 	if s.dbType == string(utils.SqlServer) {
 		sqlString = s.generateQuerySQLServer(sqlString)
@@ -65,7 +59,7 @@ func (s *SQL) generateDeleteQuery(ctx context.Context, project, col string, req
 
 // DeleteCollection drops a table
 func (s *SQL) DeleteCollection(ctx context.Context, project, col string) error {
-	query := "DROP TABLE " + project + "." + col
+	query := "DROP TABLE " + s.getDBName(project, col)
 	_, err := s.client.ExecContext(ctx, query, []interface{}{}...)
 	return err
 }
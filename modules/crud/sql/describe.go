@@ -35,18 +35,31 @@ func (s *SQL) getDescribeDetails(ctx context.Context, project, col string) ([]ut
 	switch utils.DBType(s.dbType) {
 	case utils.MySQL:
 		queryString = `select column_name as 'Field',is_nullable as 'Null',column_key as 'Key',coalesce(column_default,'') as 'Default',coalesce(column_default,'') as 'Extra',
-case when data_type = 'varchar' then concat(DATA_TYPE,'(',CHARACTER_MAXIMUM_LENGTH,')') else DATA_TYPE end as 'Type'
-from information_schema.columns
+case when data_type = 'varchar' then concat(DATA_TYPE,'(',CHARACTER_MAXIMUM_LENGTH,')') when data_type = 'enum' then COLUMN_TYPE else DATA_TYPE end as 'Type',
+'' as 'Check',
+coalesce((select k.ORDINAL_POSITION from information_schema.KEY_COLUMN_USAGE k
+    where (k.table_schema,k.table_name,k.column_name,k.constraint_name) = (c.table_schema,c.table_name,c.column_name,'PRIMARY')),0) as 'KeyOrder'
+from information_schema.columns c
 where (table_name,table_schema) = (?,?);`
 		args = append(args, col, project)
 
 	case utils.Postgres:
-		queryString = `SELECT isc.column_name AS "Field", coalesce(isc.column_default,'') AS "Default" ,isc.data_type AS "Type",isc.is_nullable AS "Null",isc.is_nullable as "Extra",
+		queryString = `SELECT isc.column_name AS "Field", coalesce(isc.column_default,'') AS "Default" ,
+CASE WHEN isc.data_type = 'ARRAY' THEN isc.udt_name ELSE isc.data_type END AS "Type",isc.is_nullable AS "Null",isc.is_nullable as "Extra",
+coalesce((SELECT pg_get_constraintdef(con.oid) FROM pg_catalog.pg_constraint con
+    JOIN pg_catalog.pg_namespace ns ON ns.oid = con.connamespace
+    WHERE ns.nspname = isc.table_schema AND con.conname = 'check__' || isc.table_name || '__' || isc.column_name), '') AS "Check",
 CASE
     WHEN istc.constraint_type = 'PRIMARY KEY' THEN 'PRI'
     WHEN istc.constraint_type = 'UNIQUE' THEN 'UNI'
     ELSE 'f'
-END AS "Key"
+END AS "Key",
+coalesce((SELECT kcu.ordinal_position FROM information_schema.key_column_usage kcu
+    JOIN information_schema.table_constraints tc ON (tc.constraint_schema, tc.constraint_name) = (kcu.constraint_schema, kcu.constraint_name)
+    WHERE tc.constraint_type = 'PRIMARY KEY' AND (kcu.table_schema, kcu.table_name, kcu.column_name) = (isc.table_schema, isc.table_name, isc.column_name)), 0) AS "KeyOrder",
+coalesce((SELECT tc.constraint_name FROM information_schema.key_column_usage kcu
+    JOIN information_schema.table_constraints tc ON (tc.constraint_schema, tc.constraint_name) = (kcu.constraint_schema, kcu.constraint_name)
+    WHERE tc.constraint_type = 'PRIMARY KEY' AND (kcu.table_schema, kcu.table_name, kcu.column_name) = (isc.table_schema, isc.table_name, isc.column_name)), '') AS "KeyConstraint"
 FROM information_schema.columns isc
     left join information_schema.constraint_column_usage cu on (cu.table_schema, cu.table_name, cu.column_name) = (isc.table_schema, isc.table_name, isc.column_name)
     left JOIN information_schema.table_constraints istc  on (istc.table_schema,istc.table_name, istc.constraint_name) = (cu.table_schema,cu.table_name, cu.constraint_name)
@@ -59,11 +72,19 @@ ORDER BY isc.ordinal_position;`
 		queryString = `SELECT DISTINCT C.COLUMN_NAME as 'Field', C.IS_NULLABLE as 'Null' , 
     case when C.DATA_TYPE = 'varchar' then concat(C.DATA_TYPE,'(',c.CHARACTER_MAXIMUM_LENGTH,')') else C.DATA_TYPE end as 'Type',
     coalesce(C.COLUMN_DEFAULT,'') as 'Default',C.DATA_TYPE as 'Extra',
+       coalesce((SELECT CK.CHECK_CLAUSE FROM INFORMATION_SCHEMA.CHECK_CONSTRAINTS AS CK
+                 WHERE CK.CONSTRAINT_SCHEMA = C.TABLE_SCHEMA AND CK.CONSTRAINT_NAME = 'check__' + C.TABLE_NAME + '__' + C.COLUMN_NAME), '') AS 'Check',
        CASE
            WHEN TC.CONSTRAINT_TYPE = 'PRIMARY KEY' THEN 'PRI'
            WHEN TC.CONSTRAINT_TYPE = 'UNIQUE' THEN 'UNI'
            ELSE isnull(TC.CONSTRAINT_TYPE,'NULL')
-           END AS 'Key'
+           END AS 'Key',
+       coalesce((SELECT K.ORDINAL_POSITION FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS K
+                   JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS T ON T.CONSTRAINT_NAME = K.CONSTRAINT_NAME
+                 WHERE T.CONSTRAINT_TYPE = 'PRIMARY KEY' AND K.TABLE_SCHEMA = C.TABLE_SCHEMA AND K.TABLE_NAME = C.TABLE_NAME AND K.COLUMN_NAME = C.COLUMN_NAME), 0) AS 'KeyOrder',
+       coalesce((SELECT K.CONSTRAINT_NAME FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS K
+                   JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS T ON T.CONSTRAINT_NAME = K.CONSTRAINT_NAME
+                 WHERE T.CONSTRAINT_TYPE = 'PRIMARY KEY' AND K.TABLE_SCHEMA = C.TABLE_SCHEMA AND K.TABLE_NAME = C.TABLE_NAME AND K.COLUMN_NAME = C.COLUMN_NAME), '') AS 'KeyConstraint'
 FROM INFORMATION_SCHEMA.COLUMNS AS C
          FULL JOIN INFORMATION_SCHEMA.CONSTRAINT_COLUMN_USAGE AS CC
                    ON C.COLUMN_NAME = CC.COLUMN_NAME
@@ -72,6 +93,15 @@ FROM INFORMATION_SCHEMA.COLUMNS AS C
 WHERE C.TABLE_SCHEMA=@p2 AND C.table_name = @p1`
 
 		args = append(args, col, project)
+	case utils.SQLite:
+		queryString = `SELECT name AS "Field", lower(type) AS "Type",
+    CASE WHEN "notnull" = 1 OR pk > 0 THEN 'NO' ELSE 'YES' END AS "Null",
+    CASE WHEN pk > 0 THEN 'PRI' ELSE '' END AS "Key",
+    coalesce(dflt_value,'') AS "Default", '' AS "Extra", '' AS "Check", pk AS "KeyOrder"
+FROM pragma_table_info(?)
+ORDER BY cid`
+
+		args = append(args, col)
 	}
 	rows, err := s.client.QueryxContext(ctx, queryString, args...)
 	if err != nil {
@@ -94,44 +124,102 @@ WHERE C.TABLE_SCHEMA=@p2 AND C.table_name = @p1`
 	if count == 0 {
 		return result, errors.New(s.dbType + ":" + col + " not found during inspection")
 	}
+
+	// SQLite doesn't describe the check constraints. Hence they are read from the statement creating the table
+	if s.dbType == string(utils.SQLite) {
+		var createStatement string
+		if err := s.client.GetContext(ctx, &createStatement, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", col); err != nil {
+			return nil, err
+		}
+		for i, field := range result {
+			result[i].FieldCheck = sqliteCheckConstraint(createStatement, "check__"+col+"__"+field.FieldName)
+		}
+	}
 	return result, nil
 }
 
+// sqliteCheckConstraint returns the expression of the named check constraint in the statement creating a table
+func sqliteCheckConstraint(createStatement, name string) string {
+	index := strings.Index(createStatement, "CONSTRAINT "+name+" CHECK (")
+	if index == -1 {
+		return ""
+	}
+	start := index + len("CONSTRAINT "+name+" CHECK (")
+
+	// Find the parenthesis closing the expression
+	depth, isQuoted := 1, false
+	for i := start; i < len(createStatement); i++ {
+		switch c := createStatement[i]; {
+		case c == '\'':
+			isQuoted = !isQuoted
+		case c == '(' && !isQuoted:
+			depth++
+		case c == ')' && !isQuoted:
+			depth--
+			if depth == 0 {
+				return createStatement[start:i]
+			}
+		}
+	}
+	return ""
+}
+
 func (s *SQL) getForeignKeyDetails(ctx context.Context, project, col string) ([]utils.ForeignKeysType, error) {
 	queryString := ""
+	args := []interface{}{project, col}
 	switch utils.DBType(s.dbType) {
 
 	case utils.MySQL:
-		queryString = "select TABLE_NAME, COLUMN_NAME, CONSTRAINT_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE WHERE REFERENCED_TABLE_SCHEMA = ? and TABLE_NAME = ?"
+		queryString = "select TABLE_NAME, COLUMN_NAME, CONSTRAINT_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME, ORDINAL_POSITION FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE WHERE REFERENCED_TABLE_SCHEMA = ? and TABLE_NAME = ? ORDER BY CONSTRAINT_NAME, ORDINAL_POSITION"
 	case utils.Postgres:
+		// The referenced columns are paired with the columns of the foreign key by their position in the referenced key
 		queryString = `SELECT
-		tc.table_name AS "TABLE_NAME", 
-		kcu.column_name AS "COLUMN_NAME", 
-		tc.constraint_name AS "CONSTRAINT_NAME", 
+		kcu.table_name AS "TABLE_NAME",
+		kcu.column_name AS "COLUMN_NAME",
+		kcu.constraint_name AS "CONSTRAINT_NAME",
 		ccu.table_name AS "REFERENCED_TABLE_NAME",
-		ccu.column_name AS "REFERENCED_COLUMN_NAME"
-	FROM 
-		information_schema.table_constraints AS tc 
+		ccu.column_name AS "REFERENCED_COLUMN_NAME",
+		kcu.ordinal_position AS "ORDINAL_POSITION"
+	FROM
+		information_schema.referential_constraints AS rc
 		JOIN information_schema.key_column_usage AS kcu
-		  ON tc.constraint_name = kcu.constraint_name
-		  AND tc.table_schema = kcu.table_schema
-		JOIN information_schema.constraint_column_usage AS ccu
-		  ON ccu.constraint_name = tc.constraint_name
-		  AND ccu.table_schema = tc.table_schema
-	WHERE tc.constraint_type = 'FOREIGN KEY'  AND tc.table_schema = $1  AND tc.table_name= $2
+		  ON kcu.constraint_name = rc.constraint_name
+		  AND kcu.constraint_schema = rc.constraint_schema
+		JOIN information_schema.key_column_usage AS ccu
+		  ON ccu.constraint_name = rc.unique_constraint_name
+		  AND ccu.constraint_schema = rc.unique_constraint_schema
+		  AND ccu.ordinal_position = kcu.position_in_unique_constraint
+	WHERE kcu.table_schema = $1 AND kcu.table_name = $2
+	ORDER BY kcu.constraint_name, kcu.ordinal_position
 	`
 	case utils.SqlServer:
-		queryString = `SELECT 
-		CCU.TABLE_NAME, CCU.COLUMN_NAME, CCU.CONSTRAINT_NAME,
-		isnull(KCU.TABLE_NAME,'') AS 'REFERENCED_TABLE_NAME', isnull(KCU.COLUMN_NAME,'') AS 'REFERENCED_COLUMN_NAME'
-	FROM INFORMATION_SCHEMA.CONSTRAINT_COLUMN_USAGE CCU
-		FULL JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
-			ON CCU.CONSTRAINT_NAME = RC.CONSTRAINT_NAME 
-		FULL JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE KCU 
-			ON KCU.CONSTRAINT_NAME = RC.CONSTRAINT_NAME  
-	WHERE CCU.TABLE_SCHEMA = @p1 AND CCU.TABLE_NAME= @p2`
-	}
-	rows, err := s.client.QueryxContext(ctx, queryString, []interface{}{project, col}...)
+		queryString = `SELECT
+		t.name AS 'TABLE_NAME', col.name AS 'COLUMN_NAME', fk.name AS 'CONSTRAINT_NAME',
+		rt.name AS 'REFERENCED_TABLE_NAME', rcol.name AS 'REFERENCED_COLUMN_NAME',
+		fkc.constraint_column_id AS 'ORDINAL_POSITION'
+	FROM sys.foreign_keys fk
+		INNER JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+		INNER JOIN sys.tables t ON t.object_id = fkc.parent_object_id
+		INNER JOIN sys.schemas s ON s.schema_id = t.schema_id
+		INNER JOIN sys.columns col ON col.object_id = fkc.parent_object_id AND col.column_id = fkc.parent_column_id
+		INNER JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+		INNER JOIN sys.columns rcol ON rcol.object_id = fkc.referenced_object_id AND rcol.column_id = fkc.referenced_column_id
+	WHERE s.name = @p1 AND t.name = @p2
+	ORDER BY fk.name, fkc.constraint_column_id`
+	case utils.SQLite:
+		// SQLite doesn't store the names of the constraints. Hence foreign keys are named after their first column
+		queryString = `SELECT
+		?1 AS "TABLE_NAME",
+		fk."from" AS "COLUMN_NAME",
+		'c_' || ?1 || '_' || (SELECT f."from" FROM pragma_foreign_key_list(?1) AS f WHERE f.id = fk.id AND f.seq = 0) AS "CONSTRAINT_NAME",
+		fk."table" AS "REFERENCED_TABLE_NAME",
+		fk."to" AS "REFERENCED_COLUMN_NAME",
+		fk.seq + 1 AS "ORDINAL_POSITION"
+	FROM pragma_foreign_key_list(?1) AS fk
+	ORDER BY fk.id, fk.seq`
+		args = []interface{}{col}
+	}
+	rows, err := s.client.QueryxContext(ctx, queryString, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +240,7 @@ func (s *SQL) getForeignKeyDetails(ctx context.Context, project, col string) ([]
 
 func (s *SQL) getIndexDetails(ctx context.Context, project, col string) ([]utils.IndexType, error) {
 	queryString := ""
+	args := []interface{}{project, col}
 	switch utils.DBType(s.dbType) {
 
 	case utils.MySQL:
@@ -202,8 +291,21 @@ func (s *SQL) getIndexDetails(ctx context.Context, project, col string) ([]utils
         	sys.schemas s ON t.schema_id = s.schema_id
 			WHERE 
      			ind.is_primary_key = 0  and s.name = @p1 and t.name = @p2 `
+	case utils.SQLite:
+		queryString = `SELECT
+		?1 AS "TABLE_NAME",
+		ii.name AS "COLUMN_NAME",
+		il.name AS "INDEX_NAME",
+		ii.seqno + 1 AS "SEQ_IN_INDEX",
+		(case when il."unique" = 1 then 'yes' else 'no' end) AS "IS_UNIQUE",
+		(case when ii."desc" = 1 then 'desc' else 'asc' end) AS "SORT"
+	FROM pragma_index_list(?1) AS il
+		JOIN pragma_index_xinfo(il.name) AS ii
+	WHERE ii.key = 1 AND il.name LIKE 'index%'
+	ORDER BY il.name, ii.seqno`
+		args = []interface{}{col}
 	}
-	rows, err := s.client.QueryxContext(ctx, queryString, []interface{}{project, col}...)
+	rows, err := s.client.QueryxContext(ctx, queryString, args...)
 	if err != nil {
 		return nil, err
 	}
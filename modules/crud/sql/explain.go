@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// Explain returns the query generated for a read request along with the plan the database would use to execute it
+func (s *SQL) Explain(ctx context.Context, project, col string, req *model.ReadRequest) (*model.ExplainResult, error) {
+	sqlString, args, err := s.generateReadQuery(ctx, project, col, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []interface{}
+	switch utils.DBType(s.dbType) {
+	case utils.SqlServer:
+		// SQL Server returns the plan instead of executing the statements once showplan is turned on for the session.
+		// Hence a dedicated connection is used
+		conn, err := s.client.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = conn.Close() }()
+
+		if _, err := conn.ExecContext(ctx, "SET SHOWPLAN_ALL ON"); err != nil {
+			return nil, err
+		}
+		defer func() { _, _ = conn.ExecContext(context.Background(), "SET SHOWPLAN_ALL OFF") }()
+
+		rows, err := conn.QueryContext(ctx, sqlString, args...)
+		if err != nil {
+			return nil, err
+		}
+		plan, err = scanPlan(&sqlx.Rows{Rows: rows, Mapper: s.client.Mapper})
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		prefix := "EXPLAIN "
+		if s.dbType == string(utils.SQLite) {
+			prefix = "EXPLAIN QUERY PLAN "
+		}
+
+		rows, err := s.client.QueryxContext(ctx, prefix+sqlString, args...)
+		if err != nil {
+			return nil, err
+		}
+		plan, err = scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.ExplainResult{Query: sqlString, Args: args, Plan: plan}, nil
+}
+
+// scanPlan reads the rows returned by an explain statement
+func scanPlan(rows *sqlx.Rows) ([]interface{}, error) {
+	defer func() { _ = rows.Close() }()
+
+	plan := []interface{}{}
+	for rows.Next() {
+		mapping := make(map[string]interface{})
+		if err := rows.MapScan(mapping); err != nil {
+			return nil, err
+		}
+
+		// Text columns are returned as bytes by some of the drivers
+		for k, v := range mapping {
+			if data, ok := v.([]byte); ok {
+				mapping[k] = string(data)
+			}
+		}
+		plan = append(plan, mapping)
+	}
+	return plan, rows.Err()
+}
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -10,19 +11,47 @@ import (
 	"time"
 
 	goqu "github.com/doug-martin/goqu/v8"
+	"github.com/doug-martin/goqu/v8/exp"
+	"github.com/lib/pq"
 
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
-func (s *SQL) generator(find map[string]interface{}) (goqu.Expression, []string) {
+// columnExpression is a column (or an expression over columns) which can be used in a where or having clause
+type columnExpression interface {
+	exp.Expression
+	exp.Aliaseable
+	exp.Comparable
+	exp.Inable
+	exp.Isable
+	exp.Likeable
+	exp.Rangeable
+}
+
+func (s *SQL) generator(find map[string]interface{}) (goqu.Expression, []string, error) {
+	return s.generateExpression(find, func(field string) columnExpression { return goqu.I(field) })
+}
+
+// generateExpression converts the find object to a goqu expression. The column function resolves the field names used in find
+func (s *SQL) generateExpression(find map[string]interface{}, column func(field string) columnExpression) (goqu.Expression, []string, error) {
 	var regxarr []string
 	array := []goqu.Expression{}
 	for k, v := range find {
 		if k == "$or" {
-			orArray := v.([]interface{})
+			orArray, ok := v.([]interface{})
+			if !ok {
+				return nil, nil, errors.New("$or operator expects an array")
+			}
 			orFinalArray := []goqu.Expression{}
 			for _, item := range orArray {
-				exp, a := s.generator(item.(map[string]interface{}))
+				itemObj, ok := item.(map[string]interface{})
+				if !ok {
+					return nil, nil, errors.New("$or operator expects an array of objects")
+				}
+				exp, a, err := s.generateExpression(itemObj, column)
+				if err != nil {
+					return nil, nil, err
+				}
 				orFinalArray = append(orFinalArray, exp)
 				regxarr = append(regxarr, a...)
 			}
@@ -30,58 +59,162 @@ func (s *SQL) generator(find map[string]interface{}) (goqu.Expression, []string)
 			array = append(array, goqu.Or(orFinalArray...))
 			continue
 		}
+		if strings.HasPrefix(k, "$") {
+			return nil, nil, fmt.Errorf("invalid operator (%s) provided in where clause", k)
+		}
+
 		val, isObj := v.(map[string]interface{})
 		if isObj {
 			for k2, v2 := range val {
-				switch k2 {
-				case "$regex":
-					switch s.dbType {
-					case "postgres":
-						regxarr = append(regxarr, fmt.Sprintf("%s = $", k))
-					case "mysql":
-						regxarr = append(regxarr, fmt.Sprintf("%s = ?", k))
-					}
-					array = append(array, goqu.I(k).Eq(v2))
-				case "$eq":
-					array = append(array, goqu.I(k).Eq(v2))
-				case "$ne":
-					array = append(array, goqu.I(k).Neq(v2))
-
-				case "$gt":
-					array = append(array, goqu.I(k).Gt(v2))
-
-				case "$gte":
-					array = append(array, goqu.I(k).Gte(v2))
-
-				case "$lt":
-					array = append(array, goqu.I(k).Lt(v2))
-
-				case "$lte":
-					array = append(array, goqu.I(k).Lte(v2))
-
-				case "$in":
-					array = append(array, goqu.I(k).In(v2))
-
-				case "$nin":
-					array = append(array, goqu.I(k).NotIn(v2))
+				e, a, err := s.generateOperatorExpression(k, k2, v2, column)
+				if err != nil {
+					return nil, nil, err
 				}
+				array = append(array, e)
+				regxarr = append(regxarr, a...)
 			}
 		} else {
-			array = append(array, goqu.I(k).Eq(v))
+			array = append(array, column(k).Eq(v))
+		}
+	}
+	return goqu.And(array...), regxarr, nil
+}
+
+// generateOperatorExpression generates the expression for a single operator applied on the field
+func (s *SQL) generateOperatorExpression(k, op string, v interface{}, column func(field string) columnExpression) (goqu.Expression, []string, error) {
+	switch op {
+	case "$regex":
+		var regxarr []string
+		switch s.dbType {
+		case "postgres":
+			regxarr = append(regxarr, fmt.Sprintf("%s = $", k))
+		case "mysql":
+			regxarr = append(regxarr, fmt.Sprintf("%s = ?", k))
+		default:
+			// SQLite and SQL Server have no regular expression operator
+			return nil, nil, fmt.Errorf("$regex operator on field (%s) is not supported by %s", k, s.dbType)
+		}
+		return column(k).Eq(v), regxarr, nil
+
+	case "$eq":
+		return column(k).Eq(v), nil, nil
+
+	case "$ne":
+		return column(k).Neq(v), nil, nil
+
+	case "$gt":
+		return column(k).Gt(v), nil, nil
+
+	case "$gte":
+		return column(k).Gte(v), nil, nil
+
+	case "$lt":
+		return column(k).Lt(v), nil, nil
+
+	case "$lte":
+		return column(k).Lte(v), nil, nil
+
+	case "$in":
+		return column(k).In(v), nil, nil
+
+	case "$nin":
+		return column(k).NotIn(v), nil, nil
+
+	case "$like":
+		return column(k).Like(v), nil, nil
+
+	case "$ilike":
+		// Only postgres has a case insensitive LIKE. Other databases compare the lower cased values instead
+		if s.dbType == string(utils.Postgres) {
+			return column(k).ILike(v), nil, nil
+		}
+		return goqu.Func("LOWER", column(k)).Like(goqu.Func("LOWER", v)), nil, nil
+
+	case "$exists":
+		exists, ok := v.(bool)
+		if !ok {
+			return nil, nil, fmt.Errorf("$exists operator on field (%s) expects a boolean", k)
+		}
+		if exists {
+			return column(k).IsNotNull(), nil, nil
+		}
+		return column(k).IsNull(), nil, nil
+
+	case "$between":
+		arr, ok := v.([]interface{})
+		if !ok || len(arr) != 2 {
+			return nil, nil, fmt.Errorf("$between operator on field (%s) expects an array of two values", k)
+		}
+		return column(k).Between(goqu.Range(arr[0], arr[1])), nil, nil
+
+	case "$contains":
+		e, err := s.generateContainsExpression(k, v, column)
+		return e, nil, err
+
+	case "$not":
+		cond, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("$not operator on field (%s) expects an object", k)
+		}
+		e, regxarr, err := s.generateExpression(map[string]interface{}{k: cond}, column)
+		if err != nil {
+			return nil, nil, err
+		}
+		return goqu.L("NOT ?", e), regxarr, nil
+
+	default:
+		return nil, nil, fmt.Errorf("invalid operator (%s) provided for field (%s)", op, k)
+	}
+}
+
+// generateContainsExpression generates the expression to check whether a JSON or an array column contains the value
+func (s *SQL) generateContainsExpression(k string, v interface{}, column func(field string) columnExpression) (goqu.Expression, error) {
+	switch utils.DBType(s.dbType) {
+	case utils.Postgres, utils.MySQL:
+		// Scalars need to be wrapped in an array to check if an array contains them
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+		default:
+			v = []interface{}{v}
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if s.dbType == string(utils.Postgres) {
+			return goqu.L("to_jsonb(?) @> ?::jsonb", column(k), string(data)), nil
+		}
+		return goqu.L("JSON_CONTAINS(?, ?)", column(k), string(data)), nil
+
+	case utils.SQLite, utils.SqlServer:
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("$contains operator on field (%s) only supports scalar values for %s", k, s.dbType)
+		}
+
+		fn := "json_each"
+		if s.dbType == string(utils.SqlServer) {
+			fn = "OPENJSON"
 		}
+		return goqu.L(fmt.Sprintf("EXISTS (SELECT 1 FROM %s(?) WHERE value = ?)", fn), column(k), v), nil
+
+	default:
+		return nil, utils.ErrUnsupportedDatabase
 	}
-	return goqu.And(array...), regxarr
 }
 
 func (s *SQL) generateWhereClause(q *goqu.SelectDataset, find map[string]interface{}) (query *goqu.SelectDataset, arr []string, err error) {
 	query = q
-	err = nil
 	if len(find) == 0 {
 		return
 	}
-	exp, arr := s.generator(find)
+	exp, arr, err := s.generator(find)
+	if err != nil {
+		return nil, nil, err
+	}
 	query = query.Where(exp)
-	return query, arr, err
+	return query, arr, nil
 }
 
 func generateRecord(temp interface{}) (goqu.Record, error) {
@@ -105,6 +238,33 @@ func (s *SQL) getDBName(project, col string) string {
 	return project + "." + col
 }
 
+// formatQuery converts the sql string generated by goqu to the syntax understood by the database
+func (s *SQL) formatQuery(sqlString string, regexArr []string) string {
+	sqlString = removeQuotes(sqlString)
+
+	for _, v := range regexArr {
+		switch s.dbType {
+		case "mysql":
+			vReplaced := strings.Replace(v, "=", "REGEXP", -1)
+			sqlString = strings.Replace(sqlString, v, vReplaced, -1)
+		case "postgres":
+			vReplaced := strings.Replace(v, "=", "~", -1)
+			sqlString = strings.Replace(sqlString, v, vReplaced, -1)
+		}
+	}
+
+	if s.dbType == string(utils.SqlServer) {
+		sqlString = s.generateQuerySQLServer(sqlString)
+	}
+	return sqlString
+}
+
+// removeQuotes strips the quotes goqu puts around the identifiers. The sqlite3 dialect quotes them with backticks
+func removeQuotes(sqlString string) string {
+	sqlString = strings.Replace(sqlString, "\"", "", -1)
+	return strings.Replace(sqlString, "`", "", -1)
+}
+
 func (s *SQL) generateQuerySQLServer(query string) string {
 	return strings.Replace(query, "$", "@p", -1)
 }
@@ -140,5 +300,72 @@ func mysqlTypeCheck(dbType utils.DBType, types []*sql.ColumnType, mapping map[st
 			}
 			mapping[colType.Name()] = string(data)
 		}
+
+		// JSON columns and postgres arrays are decoded to the values stored in them
+		if strings.EqualFold(typeName, "JSON") || typeName == "JSONB" {
+			var data []byte
+			switch v := mapping[colType.Name()].(type) {
+			case []byte:
+				data = v
+			case string:
+				data = []byte(v)
+			default:
+				continue
+			}
+			var value interface{}
+			if err := json.Unmarshal(data, &value); err != nil {
+				log.Println("Error:", err)
+				continue
+			}
+			mapping[colType.Name()] = value
+		}
+		if data, ok := mapping[colType.Name()].([]byte); ok && dbType == utils.Postgres && strings.HasPrefix(typeName, "_") {
+			value, err := parsePostgresArray(typeName, data)
+			if err != nil {
+				log.Println("Error:", err)
+				continue
+			}
+			mapping[colType.Name()] = value
+		}
+	}
+}
+
+// parsePostgresArray parses the value of a postgres array column
+func parsePostgresArray(typeName string, data []byte) ([]interface{}, error) {
+	array := []interface{}{}
+	switch typeName {
+	case "_INT2", "_INT4", "_INT8":
+		var a pq.Int64Array
+		if err := a.Scan(data); err != nil {
+			return nil, err
+		}
+		for _, v := range a {
+			array = append(array, v)
+		}
+	case "_FLOAT4", "_FLOAT8", "_NUMERIC":
+		var a pq.Float64Array
+		if err := a.Scan(data); err != nil {
+			return nil, err
+		}
+		for _, v := range a {
+			array = append(array, v)
+		}
+	case "_BOOL":
+		var a pq.BoolArray
+		if err := a.Scan(data); err != nil {
+			return nil, err
+		}
+		for _, v := range a {
+			array = append(array, v)
+		}
+	default:
+		var a pq.StringArray
+		if err := a.Scan(data); err != nil {
+			return nil, err
+		}
+		for _, v := range a {
+			array = append(array, v)
+		}
 	}
+	return array, nil
 }
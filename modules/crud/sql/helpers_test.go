@@ -0,0 +1,63 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/doug-martin/goqu/v8"
+
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateWhereClause(t *testing.T) {
+	var tests = []struct {
+		name     string
+		dbType   string
+		find     map[string]interface{}
+		want     string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{name: "like", dbType: string(utils.Postgres), find: map[string]interface{}{"name": map[string]interface{}{"$like": "a%"}}, want: "SELECT * FROM todos WHERE (name LIKE $1)", wantArgs: []interface{}{"a%"}},
+		{name: "ilike on postgres", dbType: string(utils.Postgres), find: map[string]interface{}{"name": map[string]interface{}{"$ilike": "a%"}}, want: "SELECT * FROM todos WHERE (name ILIKE $1)", wantArgs: []interface{}{"a%"}},
+		{name: "ilike on mysql", dbType: string(utils.MySQL), find: map[string]interface{}{"name": map[string]interface{}{"$ilike": "a%"}}, want: "SELECT * FROM todos WHERE (LOWER(name) LIKE LOWER(?))", wantArgs: []interface{}{"a%"}},
+		{name: "exists", dbType: string(utils.Postgres), find: map[string]interface{}{"name": map[string]interface{}{"$exists": true}}, want: "SELECT * FROM todos WHERE (name IS NOT NULL)"},
+		{name: "not exists", dbType: string(utils.Postgres), find: map[string]interface{}{"name": map[string]interface{}{"$exists": false}}, want: "SELECT * FROM todos WHERE (name IS NULL)"},
+		{name: "null equality", dbType: string(utils.Postgres), find: map[string]interface{}{"name": nil}, want: "SELECT * FROM todos WHERE (name IS NULL)"},
+		{name: "between", dbType: string(utils.Postgres), find: map[string]interface{}{"age": map[string]interface{}{"$between": []interface{}{1, 5}}}, want: "SELECT * FROM todos WHERE (age BETWEEN $1 AND $2)", wantArgs: []interface{}{int64(1), int64(5)}},
+		{name: "contains on postgres", dbType: string(utils.Postgres), find: map[string]interface{}{"tags": map[string]interface{}{"$contains": "go"}}, want: "SELECT * FROM todos WHERE to_jsonb(tags) @> $1::jsonb", wantArgs: []interface{}{`["go"]`}},
+		{name: "contains on mysql", dbType: string(utils.MySQL), find: map[string]interface{}{"meta": map[string]interface{}{"$contains": map[string]interface{}{"a": 1}}}, want: "SELECT * FROM todos WHERE JSON_CONTAINS(meta, ?)", wantArgs: []interface{}{`{"a":1}`}},
+		{name: "contains on sqlite", dbType: string(utils.SQLite), find: map[string]interface{}{"tags": map[string]interface{}{"$contains": "go"}}, want: "SELECT * FROM todos WHERE EXISTS (SELECT 1 FROM json_each(tags) WHERE value = ?)", wantArgs: []interface{}{"go"}},
+		{name: "not", dbType: string(utils.Postgres), find: map[string]interface{}{"age": map[string]interface{}{"$not": map[string]interface{}{"$gt": 5}}}, want: "SELECT * FROM todos WHERE NOT (age > $1)", wantArgs: []interface{}{int64(5)}},
+		{name: "unknown operator", dbType: string(utils.Postgres), find: map[string]interface{}{"age": map[string]interface{}{"$size": 5}}, wantErr: true},
+		{name: "unknown top level operator", dbType: string(utils.Postgres), find: map[string]interface{}{"$nor": []interface{}{}}, wantErr: true},
+		{name: "regex on sqlite", dbType: string(utils.SQLite), find: map[string]interface{}{"name": map[string]interface{}{"$regex": "^a"}}, wantErr: true},
+		{name: "regex on sqlserver", dbType: string(utils.SqlServer), find: map[string]interface{}{"name": map[string]interface{}{"$regex": "^a"}}, wantErr: true},
+		{name: "invalid exists", dbType: string(utils.Postgres), find: map[string]interface{}{"age": map[string]interface{}{"$exists": "yes"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SQL{dbType: tt.dbType}
+			query := goqu.Dialect(tt.dbType).From("todos").Prepared(true)
+			query, arr, err := s.generateWhereClause(query, tt.find)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateWhereClause() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			sqlString, args, err := query.ToSQL()
+			if err != nil {
+				t.Fatal("ToSQL() error:", err)
+			}
+			if got := s.formatQuery(sqlString, arr); got != tt.want {
+				t.Errorf("generateWhereClause() got = %v, want %v", got, tt.want)
+			}
+			if len(tt.wantArgs) > 0 && !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("generateWhereClause() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
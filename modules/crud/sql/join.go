@@ -0,0 +1,266 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// identifierRegex matches the identifiers which can be used as is in the join query
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateJoins makes sure the collections and fields used to join the other collections are plain identifiers, since
+// they are added to the join query as is
+func validateJoins(req *model.ReadRequest) error {
+	for _, join := range req.Options.Join {
+		if !identifierRegex.MatchString(join.Col) {
+			return fmt.Errorf("invalid collection (%s) provided to join", join.Col)
+		}
+		for field, joinField := range join.On {
+			if !identifierRegex.MatchString(field) || !identifierRegex.MatchString(joinField) {
+				return fmt.Errorf("invalid fields (%s, %s) provided to join collection (%s)", field, joinField, join.Col)
+			}
+		}
+	}
+	for _, key := range req.Options.Sort {
+		if !identifierRegex.MatchString(strings.TrimPrefix(key, "-")) {
+			return fmt.Errorf("invalid sort key (%s) provided in a read with joins", key)
+		}
+	}
+	return nil
+}
+
+// joinRowColumn is the name of the column numbering the rows of the read query. Distinct rows may have the same values
+// for the selected columns, hence the joined rows are grouped by this number
+const joinRowColumn = "sc_row"
+
+// joinStartColumn is the name of the marker column preceding the columns of the ith joined table
+func joinStartColumn(i int) string {
+	return fmt.Sprintf("sc_join%d_start", i)
+}
+
+// hasJoins checks if the documents of other collections need to be joined with the ones being read
+func hasJoins(req *model.ReadRequest) bool {
+	if req.Operation != utils.One && req.Operation != utils.All {
+		return false
+	}
+	return req.Options != nil && len(req.Options.Join) > 0
+}
+
+// joinFields returns the fields of the documents read which are required to join the other collections
+func joinFields(joins []*model.JoinOption) []string {
+	fields := []string{}
+	seen := map[string]bool{}
+	for _, join := range joins {
+		for field := range join.On {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// generateJoinQuery wraps the read query in a query which left joins the collections to be included. The columns of
+// each joined table are preceded by a marker column so that the rows can be split into the individual documents
+func (s *SQL) generateJoinQuery(project string, req *model.ReadRequest, readQuery string) (string, error) {
+	if err := validateJoins(req); err != nil {
+		return "", err
+	}
+
+	cols := []string{"sc_main.*"}
+	joins := make([]string, len(req.Options.Join))
+	for i, join := range req.Options.Join {
+		alias := fmt.Sprintf("sc_join%d", i)
+		cols = append(cols, fmt.Sprintf("'' AS %s", joinStartColumn(i)), alias+".*")
+
+		fields := make([]string, 0, len(join.On))
+		for field := range join.On {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		on := make([]string, len(fields))
+		for j, field := range fields {
+			on[j] = fmt.Sprintf("sc_main.%s = %s.%s", field, alias, join.On[field])
+		}
+		if join.ExcludeDeleted {
+			on = append(on, fmt.Sprintf("%s.%s IS NULL", alias, utils.SoftDeleteField))
+		}
+		joins[i] = fmt.Sprintf("LEFT JOIN %s AS %s ON %s", s.getDBName(project, join.Col), alias, strings.Join(on, " AND "))
+	}
+
+	mainQuery := fmt.Sprintf("SELECT sc_read.*, ROW_NUMBER() OVER (ORDER BY (SELECT NULL)) AS %s FROM (%s) AS sc_read", joinRowColumn, readQuery)
+	sqlQuery := fmt.Sprintf("SELECT %s FROM (%s) AS sc_main %s", strings.Join(cols, ", "), mainQuery, strings.Join(joins, " "))
+
+	// The order of the read query needs to be preserved
+	sortKeys := req.Options.Sort
+	if req.Options.Before != nil {
+		sortKeys = utils.ReverseSort(sortKeys)
+	}
+	if len(sortKeys) > 0 {
+		orderBys := make([]string, len(sortKeys))
+		for i, key := range sortKeys {
+			if strings.HasPrefix(key, "-") {
+				orderBys[i] = "sc_main." + strings.TrimPrefix(key, "-") + " DESC"
+			} else {
+				orderBys[i] = "sc_main." + key + " ASC"
+			}
+		}
+		sqlQuery += " ORDER BY " + strings.Join(orderBys, ", ")
+	}
+
+	return sqlQuery, nil
+}
+
+// readJoinedRows groups the rows of a join query into documents. The joined documents are returned in the field
+// provided in the join option
+func (s *SQL) readJoinedRows(rows *sqlx.Rows, rowTypes []*sql.ColumnType, req *model.ReadRequest) (int64, interface{}, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Find the marker columns which split the row into the individual documents
+	joins := req.Options.Join
+	bounds := make([]int, 0, len(joins)+1)
+	for i, col := range cols {
+		if col == joinStartColumn(len(bounds)) {
+			bounds = append(bounds, i)
+		}
+	}
+	if len(bounds) != len(joins) || bounds[0] == 0 || cols[bounds[0]-1] != joinRowColumn {
+		return 0, nil, errors.New("SQL: could not process the joined rows")
+	}
+	bounds = append(bounds, len(cols))
+
+	// The fields required for the join are removed from the result if they were not selected
+	var extraFields []string
+	if req.Options.Select != nil {
+		for _, field := range joinFields(joins) {
+			if _, p := req.Options.Select[field]; !p {
+				extraFields = append(extraFields, field)
+			}
+		}
+	}
+
+	docs := []interface{}{}
+	index := map[string]map[string]interface{}{}
+	seen := map[string]bool{}
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// The last column of the main document is the number of its row
+		main := bounds[0] - 1
+		key, err := json.Marshal(values[main])
+		if err != nil {
+			return 0, nil, err
+		}
+
+		doc, p := index[string(key)]
+		if !p {
+			doc = s.generateJoinedDoc(cols[:main], columnTypes(rowTypes, 0, main), values[:main])
+			for _, join := range joins {
+				if join.Type != utils.One {
+					doc[join.As] = []interface{}{}
+				}
+			}
+			index[string(key)] = doc
+			docs = append(docs, doc)
+		}
+
+		for i, join := range joins {
+			start, end := bounds[i]+1, bounds[i+1]
+			if isNullRow(values[start:end]) {
+				continue
+			}
+
+			joinKey, err := json.Marshal(values[start:end])
+			if err != nil {
+				return 0, nil, err
+			}
+
+			// Rows get repeated when multiple collections are joined
+			k := fmt.Sprintf("%s:%d:%s", key, i, joinKey)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			joinedDoc := s.generateJoinedDoc(cols[start:end], columnTypes(rowTypes, start, end), values[start:end])
+			if join.Type == utils.One {
+				if _, p := doc[join.As]; !p {
+					doc[join.As] = joinedDoc
+				}
+				continue
+			}
+			doc[join.As] = append(doc[join.As].([]interface{}), joinedDoc)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, err
+	}
+
+	for _, doc := range docs {
+		for _, field := range extraFields {
+			delete(doc.(map[string]interface{}), field)
+		}
+	}
+
+	// Rows before a cursor are read in the reverse order
+	if req.Options.Before != nil {
+		utils.ReverseDocs(docs)
+	}
+
+	if req.Operation == utils.One {
+		if len(docs) == 0 {
+			return 0, nil, errors.New("SQL: No response from db")
+		}
+		return 1, docs[0], nil
+	}
+	return int64(len(docs)), docs, nil
+}
+
+// generateJoinedDoc makes a document out of the columns of a single table in the joined row
+func (s *SQL) generateJoinedDoc(cols []string, rowTypes []*sql.ColumnType, values []interface{}) map[string]interface{} {
+	doc := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		doc[col] = values[i]
+	}
+
+	if rowTypes != nil {
+		mysqlTypeCheck(s.GetDBType(), rowTypes, doc)
+	}
+	return doc
+}
+
+func columnTypes(rowTypes []*sql.ColumnType, start, end int) []*sql.ColumnType {
+	if rowTypes == nil {
+		return nil
+	}
+	return rowTypes[start:end]
+}
+
+// isNullRow checks if all the columns of a left joined table are null, which is the case when no row matched
+func isNullRow(values []interface{}) bool {
+	for _, v := range values {
+		if v != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package sql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateJoinQuery(t *testing.T) {
+	s := SQL{dbType: string(utils.Postgres)}
+	req := &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{
+		Sort: []string{"-priority"},
+		Join: []*model.JoinOption{
+			{Col: "notes", On: map[string]string{"id": "todo_id"}, As: "notes", Type: utils.All, ExcludeDeleted: true},
+			{Col: "users", On: map[string]string{"owner": "id", "team": "team"}, As: "user", Type: utils.One},
+		},
+	}}
+
+	got, err := s.generateJoinQuery("project", req, "SELECT * FROM project.todos ORDER BY priority DESC")
+	if err != nil {
+		t.Fatal("generateJoinQuery() error:", err)
+	}
+	want := "SELECT sc_main.*, '' AS sc_join0_start, sc_join0.*, '' AS sc_join1_start, sc_join1.* FROM " +
+		"(SELECT sc_read.*, ROW_NUMBER() OVER (ORDER BY (SELECT NULL)) AS sc_row FROM (SELECT * FROM project.todos ORDER BY priority DESC) AS sc_read) AS sc_main " +
+		"LEFT JOIN project.notes AS sc_join0 ON sc_main.id = sc_join0.todo_id AND sc_join0.deleted_at IS NULL " +
+		"LEFT JOIN project.users AS sc_join1 ON sc_main.owner = sc_join1.id AND sc_main.team = sc_join1.team ORDER BY sc_main.priority DESC"
+	if got != want {
+		t.Errorf("generateJoinQuery() = %s; want %s", got, want)
+	}
+
+	// The identifiers are added to the query as is, hence they must not contain anything but letters, digits and underscores
+	for _, opts := range []*model.ReadOptions{
+		{Join: []*model.JoinOption{{Col: "notes; DROP TABLE users", On: map[string]string{"id": "todo_id"}}}},
+		{Join: []*model.JoinOption{{Col: "notes", On: map[string]string{"id": "todo_id OR 1=1"}}}},
+		{Join: []*model.JoinOption{{Col: "notes", On: map[string]string{"id) --": "todo_id"}}}},
+		{Join: []*model.JoinOption{{Col: "notes", On: map[string]string{"id": "todo_id"}}}, Sort: []string{"-priority, (SELECT 1)"}},
+	} {
+		req := &model.ReadRequest{Operation: utils.All, Options: opts}
+		if _, err := s.generateJoinQuery("project", req, "SELECT * FROM project.todos"); err == nil {
+			t.Errorf("generateJoinQuery() succeeded for the invalid join %v", opts)
+		}
+	}
+}
+
+func TestSQLite_Join(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := s.RawBatch(ctx, []string{
+		"CREATE TABLE notes (id varchar(50) PRIMARY KEY NOT NULL, todo_id varchar(50), body text);",
+		"CREATE TABLE tags (id varchar(50) PRIMARY KEY NOT NULL, todo_id varchar(50), name text);",
+		"INSERT INTO todos (id, text, priority) VALUES ('1', 'first', 1), ('2', 'second', 2), ('3', 'third', 3);",
+		"INSERT INTO notes (id, todo_id, body) VALUES ('n1', '1', 'a'), ('n2', '1', 'b'), ('n3', '2', 'c');",
+		"INSERT INTO tags (id, todo_id, name) VALUES ('t1', '1', 'x'), ('t2', '1', 'y');",
+	}); err != nil {
+		t.Fatal("could not seed tables:", err)
+	}
+
+	_, result, err := s.Read(ctx, "test", "todos", &model.ReadRequest{
+		Operation: utils.All,
+		Find:      map[string]interface{}{"priority": map[string]interface{}{"$lte": 2}},
+		Options: &model.ReadOptions{
+			Select: map[string]int32{"text": 1},
+			Sort:   []string{"id"},
+			Join: []*model.JoinOption{
+				{Col: "notes", On: map[string]string{"id": "todo_id"}, As: "notes", Type: utils.All},
+				{Col: "tags", On: map[string]string{"id": "todo_id"}, As: "tag", Type: utils.One},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("Read() error:", err)
+	}
+
+	docs := result.([]interface{})
+	if len(docs) != 2 {
+		t.Fatalf("Read() returned %d documents; want 2", len(docs))
+	}
+
+	first := docs[0].(map[string]interface{})
+	if _, p := first["id"]; p {
+		t.Errorf("Read() returned the join field which was not selected")
+	}
+	if notes := first["notes"].([]interface{}); len(notes) != 2 {
+		t.Errorf("Read() joined %d notes with the first todo; want 2", len(notes))
+	}
+	if tag := first["tag"].(map[string]interface{}); tag["id"] != "t1" && tag["id"] != "t2" {
+		t.Errorf("Read() joined tag = %v", tag)
+	}
+
+	second := docs[1].(map[string]interface{})
+	wantNotes := []interface{}{map[string]interface{}{"id": "n3", "todo_id": "2", "body": "c"}}
+	if !reflect.DeepEqual(second["notes"], wantNotes) {
+		t.Errorf("Read() joined notes = %v; want %v", second["notes"], wantNotes)
+	}
+	if _, p := second["tag"]; p {
+		t.Errorf("Read() returned a tag for a todo without tags")
+	}
+}
+
+func TestSQLite_JoinIdenticalRows(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := s.RawBatch(ctx, []string{
+		"CREATE TABLE items (id varchar(50) PRIMARY KEY NOT NULL, name text, kind text);",
+		"CREATE TABLE kinds (id varchar(50) PRIMARY KEY NOT NULL, label text);",
+		"INSERT INTO items (id, name, kind) VALUES ('1', 'same', 'k'), ('2', 'same', 'k');",
+		"INSERT INTO kinds (id, label) VALUES ('k', 'kind');",
+	}); err != nil {
+		t.Fatal("could not seed tables:", err)
+	}
+
+	// Both items have the same values for the selected columns, yet they are distinct documents
+	_, result, err := s.Read(ctx, "test", "items", &model.ReadRequest{
+		Operation: utils.All,
+		Options: &model.ReadOptions{
+			Select: map[string]int32{"name": 1, "kind": 1},
+			Join:   []*model.JoinOption{{Col: "kinds", On: map[string]string{"kind": "id"}, As: "details", Type: utils.One}},
+		},
+	})
+	if err != nil {
+		t.Fatal("Read() error:", err)
+	}
+
+	docs := result.([]interface{})
+	if len(docs) != 2 {
+		t.Fatalf("Read() returned %d documents; want 2", len(docs))
+	}
+	want := map[string]interface{}{"name": "same", "kind": "k", "details": map[string]interface{}{"id": "k", "label": "kind"}}
+	for _, doc := range docs {
+		if !reflect.DeepEqual(doc, want) {
+			t.Errorf("Read() document = %v; want %v", doc, want)
+		}
+	}
+}
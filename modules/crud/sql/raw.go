@@ -3,8 +3,10 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
@@ -35,6 +37,43 @@ func (s *SQL) RawBatch(ctx context.Context, queries []string) error {
 	return nil
 }
 
+// ApplyMigration runs the queries of a schema migration and inserts the record of the migration in a single
+// transaction. MySQL commits the transaction implicitly after every ddl statement, hence the record is only
+// inserted once the queries have been applied there
+func (s *SQL) ApplyMigration(ctx context.Context, project, col string, queries []string, record map[string]interface{}) error {
+	insertQuery, args, err := s.generateCreateQuery(ctx, project, col, &model.CreateRequest{Document: record, Operation: utils.One})
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.client.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err := doExecContext(ctx, insertQuery, args, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetValidator sets the json schema validating the documents of a collection. Sql tables are validated by their
+// schema instead
+func (s *SQL) SetValidator(ctx context.Context, project, col string, schema map[string]interface{}) error {
+	return errors.New("json schema validator cannot be set on sql databases")
+}
+
+// SetView creates a mongo view. The views of sql databases are created with raw queries instead
+func (s *SQL) SetView(ctx context.Context, project, col, source string, pipeline []interface{}) error {
+	return errors.New("mongo view cannot be set on sql databases")
+}
+
 // RawExec performs an operation for schema creation
 // NOTE: not to be exposed externally
 func (s *SQL) RawExec(ctx context.Context, query string) error {
@@ -66,6 +105,9 @@ func (s *SQL) CreateProjectIfNotExist(ctx context.Context, project string) error
 					BEGIN
     					EXEC ('CREATE SCHEMA [` + project + `] ')
 					END`
+	case utils.SQLite:
+		// SQLite does not have schemas, so there is nothing to create
+		return nil
 	default:
 		return fmt.Errorf("invalid db type (%s) provided", s.dbType)
 	}
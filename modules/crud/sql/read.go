@@ -20,12 +20,7 @@ import (
 
 // generateReadQuery makes a query for read operation
 func (s *SQL) generateReadQuery(ctx context.Context, project, col string, req *model.ReadRequest) (string, []interface{}, error) {
-	dbType := s.dbType
-	if dbType == string(utils.SqlServer) {
-		dbType = string(utils.Postgres)
-	}
-
-	dialect := goqu.Dialect(dbType)
+	dialect := goqu.Dialect(s.dialectName())
 	query := dialect.From(s.getDBName(project, col)).Prepared(true)
 	var tarr []string
 	if req.Find != nil {
@@ -45,6 +40,13 @@ func (s *SQL) generateReadQuery(ctx context.Context, project, col string, req *m
 			for key := range req.Options.Select {
 				selArray = append(selArray, key)
 			}
+
+			// The fields required to join the other collections need to be selected as well
+			for _, field := range joinFields(req.Options.Join) {
+				if _, p := req.Options.Select[field]; !p {
+					selArray = append(selArray, field)
+				}
+			}
 		}
 
 		if req.Options.Skip != nil {
@@ -55,12 +57,31 @@ func (s *SQL) generateReadQuery(ctx context.Context, project, col string, req *m
 			query = query.Limit(uint(*req.Options.Limit))
 		}
 
-		if req.Options.Sort != nil {
+		sort := req.Options.Sort
+
+		// Only read the rows after or before the cursor provided
+		if req.Options.After != nil || req.Options.Before != nil {
+			var cursorFind map[string]interface{}
+			var err error
+			cursorFind, sort, err = utils.GenerateCursorFilter(sort, req.Options.After, req.Options.Before)
+			if err != nil {
+				return "", nil, err
+			}
+
+			cursorExp, arr, err := s.generator(cursorFind)
+			if err != nil {
+				return "", nil, err
+			}
+			query = query.Where(cursorExp)
+			tarr = append(tarr, arr...)
+		}
+
+		if sort != nil {
 			// Format the order array to a suitable type
-			orderBys := make([]exp.OrderedExpression, len(req.Options.Sort))
+			orderBys := make([]exp.OrderedExpression, len(sort))
 
 			// Iterate over order array
-			for i, value := range req.Options.Sort {
+			for i, value := range sort {
 				// Add order type based on type attribute of order element
 				var e exp.OrderedExpression
 				if strings.HasPrefix(value, "-") {
@@ -89,27 +110,23 @@ func (s *SQL) generateReadQuery(ctx context.Context, project, col string, req *m
 		query = query.Select(selArray...)
 	}
 
+	// A single row needs to be joined for the one operation
+	if hasJoins(req) && req.Operation == utils.One {
+		query = query.Limit(1)
+	}
+
 	// Generate the sql string and arguments
 	sqlString, args, err := query.ToSQL()
 	if err != nil {
 		return "", nil, err
 	}
-	sqlString = strings.Replace(sqlString, "\"", "", -1)
+	sqlString = s.formatQuery(sqlString, tarr)
 
-	for _, v := range tarr {
-		switch s.dbType {
-		case "mysql":
-			vReplaced := strings.Replace(v, "=", "REGEXP", -1)
-			sqlString = strings.Replace(sqlString, v, vReplaced, -1)
-		case "postgres":
-			vReplaced := strings.Replace(v, "=", "~", -1)
-			sqlString = strings.Replace(sqlString, v, vReplaced, -1)
+	if hasJoins(req) {
+		sqlString, err = s.generateJoinQuery(project, req, sqlString)
+		if err != nil {
+			return "", nil, err
 		}
-
-	}
-
-	if s.dbType == string(utils.SqlServer) {
-		sqlString = s.generateQuerySQLServer(sqlString)
 	}
 	return sqlString, args, nil
 }
@@ -140,10 +157,14 @@ func (s *SQL) read(ctx context.Context, project, col string, req *model.ReadRequ
 	var rowTypes []*sql.ColumnType
 
 	switch s.GetDBType() {
-	case utils.MySQL, utils.Postgres:
+	case utils.MySQL, utils.Postgres, utils.SQLite:
 		rowTypes, _ = rows.ColumnTypes()
 	}
 
+	if hasJoins(req) {
+		return s.readJoinedRows(rows, rowTypes, req)
+	}
+
 	switch req.Operation {
 	case utils.Count:
 		mapping := make(map[string]interface{})
@@ -157,7 +178,7 @@ func (s *SQL) read(ctx context.Context, project, col string, req *model.ReadRequ
 		}
 
 		switch s.GetDBType() {
-		case utils.MySQL, utils.Postgres:
+		case utils.MySQL, utils.Postgres, utils.SQLite:
 			mysqlTypeCheck(s.GetDBType(), rowTypes, mapping)
 		}
 
@@ -179,7 +200,7 @@ func (s *SQL) read(ctx context.Context, project, col string, req *model.ReadRequ
 		}
 
 		switch s.GetDBType() {
-		case utils.MySQL, utils.Postgres:
+		case utils.MySQL, utils.Postgres, utils.SQLite:
 			mysqlTypeCheck(s.GetDBType(), rowTypes, mapping)
 		}
 
@@ -200,13 +221,18 @@ func (s *SQL) read(ctx context.Context, project, col string, req *model.ReadRequ
 			}
 
 			switch s.GetDBType() {
-			case utils.MySQL, utils.Postgres:
+			case utils.MySQL, utils.Postgres, utils.SQLite:
 				mysqlTypeCheck(s.GetDBType(), rowTypes, mapping)
 			}
 
 			array = append(array, mapping)
 		}
 
+		// Rows before a cursor are read in the reverse order
+		if req.Options != nil && req.Options.Before != nil {
+			utils.ReverseDocs(array)
+		}
+
 		return count, array, nil
 
 	default:
@@ -6,11 +6,13 @@ import (
 	"log"
 	"time"
 
+	_ "github.com/doug-martin/goqu/v8/dialect/sqlite3" // Dialect for sqlite
 	"github.com/jmoiron/sqlx"
 
 	_ "github.com/denisenkom/go-mssqldb" //Import for MsSQL
 	_ "github.com/go-sql-driver/mysql"   // Import for MySQL
 	_ "github.com/lib/pq"                // Import for postgres
+	_ "github.com/mattn/go-sqlite3"      // Import for sqlite
 
 	"github.com/spaceuptech/space-cloud/utils"
 )
@@ -22,11 +24,12 @@ type SQL struct {
 	client             *sqlx.DB
 	dbType             string
 	removeProjectScope bool
+	pool               utils.PoolConfig
 }
 
 // Init initialises a new sql instance
-func Init(dbType utils.DBType, enabled, removeProjectScope bool, connection string) (s *SQL, err error) {
-	s = &SQL{enabled: enabled, removeProjectScope: removeProjectScope, connection: connection, client: nil}
+func Init(dbType utils.DBType, enabled, removeProjectScope bool, connection string, pool utils.PoolConfig) (s *SQL, err error) {
+	s = &SQL{enabled: enabled, removeProjectScope: removeProjectScope, connection: connection, client: nil, pool: pool}
 
 	switch dbType {
 	case utils.Postgres:
@@ -38,9 +41,13 @@ func Init(dbType utils.DBType, enabled, removeProjectScope bool, connection stri
 	case utils.SqlServer:
 		s.dbType = "sqlserver"
 
+	case utils.SQLite:
+		// SQLite has no notion of schemas. Hence tables are never scoped by project
+		s.dbType = "sqlite"
+		s.removeProjectScope = true
+
 	default:
-		err = utils.ErrUnsupportedDatabase
-		return
+		return nil, utils.ErrUnsupportedDatabase
 	}
 
 	if s.enabled {
@@ -68,6 +75,8 @@ func (s *SQL) GetDBType() utils.DBType {
 		return utils.MySQL
 	case "sqlserver":
 		return utils.SqlServer
+	case "sqlite":
+		return utils.SQLite
 	}
 
 	return utils.MySQL
@@ -95,16 +104,64 @@ func (s *SQL) connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeOut)
 	defer cancel()
 
-	sql, err := sqlx.Open(s.dbType, s.connection)
+	sql, err := sqlx.Open(s.driverName(), s.connection)
 	if err != nil {
 		return err
 	}
 
+	// Apply the pool settings which have been provided
+	if s.pool.MaxOpenConns > 0 {
+		sql.SetMaxOpenConns(s.pool.MaxOpenConns)
+	}
+	if s.pool.MaxIdleConns > 0 {
+		sql.SetMaxIdleConns(s.pool.MaxIdleConns)
+	}
+	if s.pool.ConnMaxLifetime > 0 {
+		sql.SetConnMaxLifetime(s.pool.ConnMaxLifetime)
+	}
+
 	s.client = sql
 
 	return sql.PingContext(ctx)
 }
 
+// GetPoolStats returns the current state of the connection pool
+func (s *SQL) GetPoolStats() utils.PoolStats {
+	if s.client == nil {
+		return utils.PoolStats{}
+	}
+
+	stats := s.client.Stats()
+	return utils.PoolStats{
+		MaxOpenConns: stats.MaxOpenConnections,
+		OpenConns:    stats.OpenConnections,
+		InUse:        stats.InUse,
+		Idle:         stats.Idle,
+		WaitCount:    stats.WaitCount,
+		WaitDuration: int64(stats.WaitDuration / time.Millisecond),
+	}
+}
+
+// driverName returns the name with which the database driver has been registered
+func (s *SQL) driverName() string {
+	if s.dbType == string(utils.SQLite) {
+		return "sqlite3"
+	}
+	return s.dbType
+}
+
+// dialectName returns the name of the goqu dialect used to generate the queries. SQL Server queries are generated
+// with the postgres dialect and converted afterwards
+func (s *SQL) dialectName() string {
+	switch utils.DBType(s.dbType) {
+	case utils.SqlServer:
+		return string(utils.Postgres)
+	case utils.SQLite:
+		return "sqlite3"
+	}
+	return s.dbType
+}
+
 type executor interface {
 	PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error)
 }
@@ -0,0 +1,284 @@
+package sql
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func initSQLite(t *testing.T) (*SQL, func()) {
+	dir, err := ioutil.TempDir("", "space-cloud-sqlite")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+
+	s, err := Init(utils.SQLite, true, false, filepath.Join(dir, "test.db"), utils.PoolConfig{})
+	if err != nil {
+		t.Fatal("could not initialise sqlite:", err)
+	}
+
+	ctx := context.Background()
+	if err := s.RawBatch(ctx, []string{
+		"CREATE TABLE todos (id varchar(50) PRIMARY KEY NOT NULL, text text, priority bigint);",
+		"CREATE UNIQUE INDEX index__todos__text ON todos (text asc)",
+	}); err != nil {
+		t.Fatal("could not create table:", err)
+	}
+
+	return s, func() {
+		_ = s.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestSQLite_Crud(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	docs := []interface{}{
+		map[string]interface{}{"id": "1", "text": "first", "priority": 1},
+		map[string]interface{}{"id": "2", "text": "second", "priority": 2},
+		map[string]interface{}{"id": "3", "text": "third", "priority": 3},
+	}
+	if n, err := s.Create(ctx, "test", "todos", &model.CreateRequest{Operation: utils.All, Document: docs}); err != nil || n != 3 {
+		t.Fatalf("Create() = (%d, %v); want (3, nil)", n, err)
+	}
+
+	if _, err := s.Update(ctx, "test", "todos", &model.UpdateRequest{
+		Operation: utils.All,
+		Find:      map[string]interface{}{"id": "1"},
+		Update:    map[string]interface{}{"$inc": map[string]interface{}{"priority": 10}},
+	}); err != nil {
+		t.Fatal("Update() $inc error:", err)
+	}
+
+	if _, err := s.Update(ctx, "test", "todos", &model.UpdateRequest{
+		Operation: utils.All,
+		Find:      map[string]interface{}{"id": "2"},
+		Update:    map[string]interface{}{"$max": map[string]interface{}{"priority": 20}},
+	}); err != nil {
+		t.Fatal("Update() $max error:", err)
+	}
+
+	_, result, err := s.Read(ctx, "test", "todos", &model.ReadRequest{
+		Operation: utils.All,
+		Find:      map[string]interface{}{"priority": map[string]interface{}{"$gt": 5}},
+		Options:   &model.ReadOptions{Sort: []string{"id"}},
+	})
+	if err != nil {
+		t.Fatal("Read() error:", err)
+	}
+	rows := result.([]interface{})
+	if len(rows) != 2 {
+		t.Fatalf("Read() returned %d rows; want 2", len(rows))
+	}
+	if p := rows[0].(map[string]interface{})["priority"]; p != int64(11) {
+		t.Errorf("Read() priority of first row = %v; want 11", p)
+	}
+
+	if _, err := s.Delete(ctx, "test", "todos", &model.DeleteRequest{Operation: utils.All, Find: map[string]interface{}{"id": "3"}}); err != nil {
+		t.Fatal("Delete() error:", err)
+	}
+
+	count, _, err := s.Read(ctx, "test", "todos", &model.ReadRequest{Operation: utils.Count})
+	if err != nil || count != 2 {
+		t.Fatalf("Read() count = (%d, %v); want (2, nil)", count, err)
+	}
+}
+
+func TestSQLite_Batch(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	counts, err := s.Batch(ctx, "test", &model.BatchRequest{Requests: []model.AllRequest{
+		{Type: string(utils.Create), Col: "todos", Operation: utils.One, Document: map[string]interface{}{"id": "1", "text": "first"}},
+		{Type: string(utils.Update), Col: "todos", Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$set": map[string]interface{}{"text": "updated"}}},
+	}})
+	if err != nil {
+		t.Fatal("Batch() error:", err)
+	}
+	if len(counts) != 2 || counts[0] != 1 || counts[1] != 1 {
+		t.Errorf("Batch() counts = %v; want [1 1]", counts)
+	}
+}
+
+func TestSQLite_BatchRollback(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// The second create violates the primary key, hence the first one must get rolled back as well
+	_, err := s.Batch(ctx, "test", &model.BatchRequest{Requests: []model.AllRequest{
+		{Type: string(utils.Create), Col: "todos", Operation: utils.One, Document: map[string]interface{}{"id": "1", "text": "first"}},
+		{Type: string(utils.Create), Col: "todos", Operation: utils.One, Document: map[string]interface{}{"id": "1", "text": "second"}},
+	}})
+	batchErr, ok := err.(*utils.BatchError)
+	if !ok {
+		t.Fatalf("Batch() error = %v; want *utils.BatchError", err)
+	}
+	if batchErr.Index != 1 {
+		t.Errorf("Batch() failing index = %d; want 1", batchErr.Index)
+	}
+	if len(batchErr.Counts) != 0 {
+		t.Errorf("Batch() applied counts = %v; want none", batchErr.Counts)
+	}
+
+	count, _, err := s.Read(ctx, "test", "todos", &model.ReadRequest{Operation: utils.Count})
+	if err != nil || count != 0 {
+		t.Fatalf("Read() count = (%d, %v); want (0, nil)", count, err)
+	}
+}
+
+func TestSQLite_ApplyMigration(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := s.ApplyMigration(ctx, "test", "todos", []string{"CREATE TABLE notes (id varchar(50) PRIMARY KEY NOT NULL);"}, map[string]interface{}{"id": "1", "text": "notes"}); err != nil {
+		t.Fatal("ApplyMigration() error:", err)
+	}
+	if _, _, err := s.Read(ctx, "test", "notes", &model.ReadRequest{Operation: utils.Count}); err != nil {
+		t.Errorf("Read() error = %v; want the table created by the migration", err)
+	}
+
+	// The record violates the primary key, hence the table must not be created either
+	if err := s.ApplyMigration(ctx, "test", "todos", []string{"CREATE TABLE tags (id varchar(50) PRIMARY KEY NOT NULL);"}, map[string]interface{}{"id": "1", "text": "tags"}); err == nil {
+		t.Fatal("ApplyMigration() succeeded with a duplicate record")
+	}
+	if _, _, err := s.Read(ctx, "test", "tags", &model.ReadRequest{Operation: utils.Count}); err == nil {
+		t.Error("Read() succeeded on the table of a migration which could not be recorded")
+	}
+}
+
+func TestSQLite_CursorPagination(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	docs := []interface{}{
+		map[string]interface{}{"id": "1", "text": "first", "priority": 2},
+		map[string]interface{}{"id": "2", "text": "second", "priority": 1},
+		map[string]interface{}{"id": "3", "text": "third", "priority": 2},
+		map[string]interface{}{"id": "4", "text": "fourth", "priority": 1},
+	}
+	if _, err := s.Create(ctx, "test", "todos", &model.CreateRequest{Operation: utils.All, Document: docs}); err != nil {
+		t.Fatal("Create() error:", err)
+	}
+
+	sort := []string{"-priority", "id"}
+	cursor, err := utils.EncodeCursor(sort, map[string]interface{}{"id": "3", "priority": int64(2)})
+	if err != nil {
+		t.Fatal("EncodeCursor() error:", err)
+	}
+
+	ids := func(result interface{}) []string {
+		var arr []string
+		for _, doc := range result.([]interface{}) {
+			arr = append(arr, doc.(map[string]interface{})["id"].(string))
+		}
+		return arr
+	}
+
+	limit := int64(2)
+	_, result, err := s.Read(ctx, "test", "todos", &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: sort, Limit: &limit, After: &cursor}})
+	if err != nil {
+		t.Fatal("Read() after cursor error:", err)
+	}
+	if got := ids(result); !reflect.DeepEqual(got, []string{"2", "4"}) {
+		t.Errorf("Read() after cursor = %v; want [2 4]", got)
+	}
+
+	_, result, err = s.Read(ctx, "test", "todos", &model.ReadRequest{Operation: utils.All, Options: &model.ReadOptions{Sort: sort, Limit: &limit, Before: &cursor}})
+	if err != nil {
+		t.Fatal("Read() before cursor error:", err)
+	}
+	if got := ids(result); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("Read() before cursor = %v; want [1]", got)
+	}
+}
+
+func TestSQLite_DescribeTable(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	fields, _, indexes, err := s.DescribeTable(ctx, "test", "todos")
+	if err != nil {
+		t.Fatal("DescribeTable() error:", err)
+	}
+	if len(fields) != 3 {
+		t.Fatalf("DescribeTable() returned %d fields; want 3", len(fields))
+	}
+	if fields[0].FieldName != "id" || fields[0].FieldType != "varchar(50)" || fields[0].FieldKey != "PRI" || fields[0].FieldNull != "NO" {
+		t.Errorf("DescribeTable() id field = %+v", fields[0])
+	}
+	if len(indexes) != 1 || indexes[0].IndexName != "text" || indexes[0].IsUnique != "yes" || indexes[0].Sort != "asc" {
+		t.Errorf("DescribeTable() indexes = %+v", indexes)
+	}
+
+	cols, err := s.GetCollections(ctx, "test")
+	if err != nil {
+		t.Fatal("GetCollections() error:", err)
+	}
+	if len(cols) != 1 || cols[0].TableName != "todos" {
+		t.Errorf("GetCollections() = %v; want [todos]", cols)
+	}
+}
+
+func TestSQLite_PoolSettings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-sqlite")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	s, err := Init(utils.SQLite, true, false, filepath.Join(dir, "test.db"), utils.PoolConfig{MaxOpenConns: 3, MaxIdleConns: 2})
+	if err != nil {
+		t.Fatal("could not initialise sqlite:", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	stats := s.GetPoolStats()
+	if stats.MaxOpenConns != 3 {
+		t.Errorf("max open connections not applied; got %d", stats.MaxOpenConns)
+	}
+	if stats.OpenConns != 1 || stats.Idle != 1 || stats.InUse != 0 {
+		t.Errorf("unexpected pool stats after connecting; got %+v", stats)
+	}
+}
+
+func TestSQLite_Explain(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	result, err := s.Explain(context.Background(), "test", "todos", &model.ReadRequest{
+		Operation: utils.All,
+		Find:      map[string]interface{}{"text": "first"},
+	})
+	if err != nil {
+		t.Fatal("Explain() error:", err)
+	}
+
+	if want := "SELECT * FROM todos WHERE (text = ?)"; result.Query != want {
+		t.Errorf("Explain() query = %v; want %v", result.Query, want)
+	}
+	if !reflect.DeepEqual(result.Args, []interface{}{"first"}) {
+		t.Errorf("Explain() args = %v; want [first]", result.Args)
+	}
+	if plan, ok := result.Plan.([]interface{}); !ok || len(plan) == 0 {
+		t.Errorf("Explain() plan = %v; want the rows of the query plan", result.Plan)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,6 +28,7 @@ func (s *SQL) Update(ctx context.Context, project, col string, req *model.Update
 	}
 	count, err := s.update(ctx, project, col, req, tx)
 	if err != nil {
+		_ = tx.Rollback()
 		return 0, err
 	}
 	return count, tx.Commit() // commit the Batch
@@ -41,8 +43,16 @@ func (s *SQL) update(ctx context.Context, project, col string, req *model.Update
 	}
 	switch req.Operation {
 	case utils.All:
-		var count int64
+		// The operators are applied in a fixed order so that $set, which bumps the version of a versioned row,
+		// is applied last. The rows would stop matching the find clause for the operators applied after it otherwise
+		ops := make([]string, 0, len(req.Update))
 		for k := range req.Update {
+			ops = append(ops, k)
+		}
+		sort.Strings(ops)
+
+		var count int64
+		for _, k := range ops {
 			switch k {
 			case "$set", "$inc", "$mul", "$max", "$min", "$currentDate":
 				sqlQuery, args, err := s.generateUpdateQuery(ctx, project, col, req, k)
@@ -65,6 +75,11 @@ func (s *SQL) update(ctx context.Context, project, col string, req *model.Update
 		return count, nil
 
 	case utils.Upsert:
+		if isNativeUpsertPossible(req) {
+			return s.upsert(ctx, project, col, req, executor)
+		}
+
+		// Fall back to reading the row first when the keys of the row are not known
 		count, _, err := s.read(ctx, project, col, &model.ReadRequest{Find: req.Find, Operation: utils.All}, executor)
 		if err != nil {
 			return 0, err
@@ -126,11 +141,7 @@ func (s *SQL) update(ctx context.Context, project, col string, req *model.Update
 func (s *SQL) generateUpdateQuery(ctx context.Context, project, col string, req *model.UpdateRequest, op string) (string, []interface{}, error) {
 	// Generate a prepared query builder
 
-	dbType := s.dbType
-	if dbType == string(utils.SqlServer) {
-		dbType = string(utils.Postgres)
-	}
-	dialect := goqu.Dialect(dbType)
+	dialect := goqu.Dialect(s.dialectName())
 	query := dialect.From(s.getDBName(project, col))
 	if op == "$set" {
 		query = query.Prepared(true)
@@ -171,7 +182,7 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, project, col string, req
 		return "", nil, err
 	}
 
-	sqlString = strings.Replace(sqlString, "\"", "", -1)
+	sqlString = removeQuotes(sqlString)
 	switch op {
 	case "$set":
 	case "$inc":
@@ -191,20 +202,30 @@ func (s *SQL) generateUpdateQuery(ctx context.Context, project, col string, req
 			sqlString = strings.Replace(sqlString, k+"="+val, k+"="+k+"*"+val, -1)
 		}
 	case "$max":
+		// SQLite uses the multi-argument form of MAX instead of GREATEST
+		fn := "GREATEST"
+		if s.dbType == string(utils.SQLite) {
+			fn = "MAX"
+		}
 		for k, v := range m {
 			val, err := numToString(v)
 			if err != nil {
 				return "", nil, err
 			}
-			sqlString = strings.Replace(sqlString, k+"="+val, k+"=GREATEST("+k+","+val+")", -1)
+			sqlString = strings.Replace(sqlString, k+"="+val, k+"="+fn+"("+k+","+val+")", -1)
 		}
 	case "$min":
+		// SQLite uses the multi-argument form of MIN instead of LEAST
+		fn := "LEAST"
+		if s.dbType == string(utils.SQLite) {
+			fn = "MIN"
+		}
 		for k, v := range m {
 			val, err := numToString(v)
 			if err != nil {
 				return "", nil, err
 			}
-			sqlString = strings.Replace(sqlString, k+"="+val, k+"=LEAST("+k+","+val+")", -1)
+			sqlString = strings.Replace(sqlString, k+"="+val, k+"="+fn+"("+k+","+val+")", -1)
 		}
 	case "$currentDate":
 		for k, v := range m {
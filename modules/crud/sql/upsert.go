@@ -0,0 +1,140 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// isNativeUpsertPossible checks whether the upsert can be performed in a single statement. This requires the
+// keys identifying the row and an update which only sets fields
+func isNativeUpsertPossible(req *model.UpdateRequest) bool {
+	if len(req.ConflictKeys) == 0 || len(req.Update) != 1 {
+		return false
+	}
+	_, ok := req.Update["$set"].(map[string]interface{})
+	return ok
+}
+
+// upsert inserts the row or updates it if a row with the same conflict keys already exists in a single statement
+func (s *SQL) upsert(ctx context.Context, project, col string, req *model.UpdateRequest, executor executor) (int64, error) {
+	sqlQuery, args, err := s.generateUpsertQuery(ctx, project, col, req)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := doExecContext(ctx, sqlQuery, args, executor)
+	if err != nil {
+		return 0, err
+	}
+
+	// MySQL reports 2 rows as affected when an existing row gets updated
+	n, err := res.RowsAffected()
+	if n > 1 {
+		n = 1
+	}
+	return n, err
+}
+
+// generateUpsertQuery makes the query for the upsert operation
+func (s *SQL) generateUpsertQuery(ctx context.Context, project, col string, req *model.UpdateRequest) (string, []interface{}, error) {
+	doc := utils.GenerateUpsertDoc(req.Find, req.Update)
+
+	isConflictKey := map[string]bool{}
+	for _, key := range req.ConflictKeys {
+		if _, p := doc[key]; !p {
+			return "", nil, fmt.Errorf("value of key (%s) is required for upsert", key)
+		}
+		isConflictKey[key] = true
+	}
+
+	// The columns to be updated if the row already exists
+	updateCols := []string{}
+	for k := range doc {
+		if !isConflictKey[k] {
+			updateCols = append(updateCols, k)
+		}
+	}
+	sort.Strings(updateCols)
+
+	if s.dbType == string(utils.SqlServer) {
+		return s.generateMergeQuery(project, col, doc, req.ConflictKeys, updateCols)
+	}
+
+	sqlQuery, args, err := s.generateCreateQuery(ctx, project, col, &model.CreateRequest{Document: doc, Operation: utils.One})
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch utils.DBType(s.dbType) {
+	case utils.MySQL:
+		set := make([]string, len(updateCols))
+		for i, c := range updateCols {
+			set[i] = fmt.Sprintf("%s = VALUES(%s)", c, c)
+		}
+		if len(set) == 0 {
+			// A no op update makes sure existing rows do not result in an error
+			set = []string{fmt.Sprintf("%s = %s", req.ConflictKeys[0], req.ConflictKeys[0])}
+		}
+		sqlQuery += " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+
+	case utils.Postgres, utils.SQLite:
+		sqlQuery += " ON CONFLICT (" + strings.Join(req.ConflictKeys, ", ") + ")"
+		if len(updateCols) == 0 {
+			sqlQuery += " DO NOTHING"
+			break
+		}
+
+		set := make([]string, len(updateCols))
+		for i, c := range updateCols {
+			set[i] = fmt.Sprintf("%s = EXCLUDED.%s", c, c)
+		}
+		sqlQuery += " DO UPDATE SET " + strings.Join(set, ", ")
+
+	default:
+		return "", nil, utils.ErrUnsupportedDatabase
+	}
+
+	return sqlQuery, args, nil
+}
+
+// generateMergeQuery makes the MERGE query used to upsert a row in SQL Server
+func (s *SQL) generateMergeQuery(project, col string, doc map[string]interface{}, conflictKeys, updateCols []string) (string, []interface{}, error) {
+	cols := make([]string, 0, len(doc))
+	for k := range doc {
+		cols = append(cols, k)
+	}
+	sort.Strings(cols)
+
+	args := make([]interface{}, len(cols))
+	placeholders := make([]string, len(cols))
+	values := make([]string, len(cols))
+	for i, c := range cols {
+		args[i] = doc[c]
+		placeholders[i] = fmt.Sprintf("@p%d", i+1)
+		values[i] = "source." + c
+	}
+
+	on := make([]string, len(conflictKeys))
+	for i, k := range conflictKeys {
+		on[i] = fmt.Sprintf("target.%s = source.%s", k, k)
+	}
+
+	sqlQuery := fmt.Sprintf("MERGE INTO %s AS target USING (VALUES (%s)) AS source (%s) ON %s",
+		s.getDBName(project, col), strings.Join(placeholders, ", "), strings.Join(cols, ", "), strings.Join(on, " AND "))
+
+	if len(updateCols) > 0 {
+		set := make([]string, len(updateCols))
+		for i, c := range updateCols {
+			set[i] = fmt.Sprintf("target.%s = source.%s", c, c)
+		}
+		sqlQuery += " WHEN MATCHED THEN UPDATE SET " + strings.Join(set, ", ")
+	}
+
+	sqlQuery += fmt.Sprintf(" WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);", strings.Join(cols, ", "), strings.Join(values, ", "))
+	return sqlQuery, args, nil
+}
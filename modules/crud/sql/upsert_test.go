@@ -0,0 +1,122 @@
+package sql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGenerateUpsertQuery(t *testing.T) {
+	req := &model.UpdateRequest{
+		Operation:    utils.Upsert,
+		Find:         map[string]interface{}{"id": "1"},
+		Update:       map[string]interface{}{"$set": map[string]interface{}{"text": "hello", "done": true}},
+		ConflictKeys: []string{"id"},
+	}
+
+	var tests = []struct {
+		name     string
+		dbType   string
+		req      *model.UpdateRequest
+		want     string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "postgres",
+			dbType:   string(utils.Postgres),
+			req:      req,
+			want:     "INSERT INTO proj.todos (done, id, text) VALUES ($1, $2, $3) ON CONFLICT (id) DO UPDATE SET done = EXCLUDED.done, text = EXCLUDED.text",
+			wantArgs: []interface{}{true, "1", "hello"},
+		},
+		{
+			name:     "mysql",
+			dbType:   string(utils.MySQL),
+			req:      req,
+			want:     "INSERT INTO proj.todos (done, id, text) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE done = VALUES(done), text = VALUES(text)",
+			wantArgs: []interface{}{true, "1", "hello"},
+		},
+		{
+			name:     "sqlserver",
+			dbType:   string(utils.SqlServer),
+			req:      req,
+			want:     "MERGE INTO proj.todos AS target USING (VALUES (@p1, @p2, @p3)) AS source (done, id, text) ON target.id = source.id WHEN MATCHED THEN UPDATE SET target.done = source.done, target.text = source.text WHEN NOT MATCHED THEN INSERT (done, id, text) VALUES (source.done, source.id, source.text);",
+			wantArgs: []interface{}{true, "1", "hello"},
+		},
+		{
+			name:   "postgres with keys only",
+			dbType: string(utils.Postgres),
+			req: &model.UpdateRequest{
+				Operation:    utils.Upsert,
+				Find:         map[string]interface{}{"id": map[string]interface{}{"$eq": "1"}},
+				Update:       map[string]interface{}{"$set": map[string]interface{}{}},
+				ConflictKeys: []string{"id"},
+			},
+			want:     "INSERT INTO proj.todos (id) VALUES ($1) ON CONFLICT (id) DO NOTHING",
+			wantArgs: []interface{}{"1"},
+		},
+		{
+			name:   "missing conflict key",
+			dbType: string(utils.Postgres),
+			req: &model.UpdateRequest{
+				Operation:    utils.Upsert,
+				Find:         map[string]interface{}{"text": "hello"},
+				Update:       map[string]interface{}{"$set": map[string]interface{}{"done": true}},
+				ConflictKeys: []string{"id"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SQL{dbType: tt.dbType}
+			got, args, err := s.generateUpsertQuery(context.Background(), "proj", "todos", tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generateUpsertQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("generateUpsertQuery() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("generateUpsertQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSQLite_Upsert(t *testing.T) {
+	s, cleanup := initSQLite(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	upsert := func(text string) {
+		if _, err := s.Update(ctx, "test", "todos", &model.UpdateRequest{
+			Operation:    utils.Upsert,
+			Find:         map[string]interface{}{"id": "1"},
+			Update:       map[string]interface{}{"$set": map[string]interface{}{"text": text}},
+			ConflictKeys: []string{"id"},
+		}); err != nil {
+			t.Fatal("Update() upsert error:", err)
+		}
+	}
+
+	upsert("first")
+	upsert("second")
+
+	_, result, err := s.Read(ctx, "test", "todos", &model.ReadRequest{Operation: utils.All})
+	if err != nil {
+		t.Fatal("Read() error:", err)
+	}
+	rows := result.([]interface{})
+	if len(rows) != 1 || rows[0].(map[string]interface{})["text"] != "second" {
+		t.Errorf("Read() after upserts = %v; want a single row with text second", rows)
+	}
+}
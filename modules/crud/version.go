@@ -0,0 +1,86 @@
+package crud
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// prepareVersionedUpdate bumps the version of the documents of a collection having a version field. The update is
+// made conditional on the version expected by the request, which is taken from the find clause or from the $set
+// operator of the update. It returns whether the update is conditional. Upserts are left untouched
+func (m *Module) prepareVersionedUpdate(dbAlias, col string, req *model.UpdateRequest) (bool, error) {
+	if m.schema == nil || req.Operation == utils.Upsert {
+		return false, nil
+	}
+
+	field, ok := m.schema.GetVersionField(dbAlias, col)
+	if !ok {
+		return false, nil
+	}
+
+	find := copyMap(req.Find)
+	update := copyMap(req.Update)
+	set := copyMap(toMap(update["$set"]))
+
+	expected, isConditional := find[field]
+	if _, isOperator := expected.(map[string]interface{}); isOperator {
+		isConditional = false
+	}
+	if !isConditional {
+		expected, isConditional = set[field]
+	}
+
+	if isConditional {
+		version, err := toVersion(expected)
+		if err != nil {
+			return false, err
+		}
+
+		find[field] = version
+		set[field] = version + 1
+	} else {
+		// The version can only be bumped when the request doesn't care about the version being updated
+		inc := copyMap(toMap(update["$inc"]))
+		inc[field] = 1
+		update["$inc"] = inc
+	}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+
+	req.Find = find
+	req.Update = update
+	return isConditional, nil
+}
+
+func toVersion(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v == math.Trunc(v) {
+			return int64(v), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid version (%v) provided", value)
+}
+
+func toMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	newMap := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		newMap[k] = v
+	}
+	return newMap
+}
@@ -0,0 +1,125 @@
+package crud
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud/sql"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+type versionSchema struct{}
+
+func (versionSchema) GetConflictKeys(dbAlias, col string, find map[string]interface{}) ([]string, bool) {
+	return nil, false
+}
+
+func (versionSchema) GetVersionField(dbAlias, col string) (string, bool) {
+	return "version", col == "todos"
+}
+
+func TestModule_VersionedUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-version")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, "test.db")
+	s, err := sql.Init(utils.SQLite, true, true, path, utils.PoolConfig{})
+	if err != nil {
+		t.Fatal("could not initialise sqlite:", err)
+	}
+	if err := s.RawBatch(context.Background(), []string{
+		"CREATE TABLE todos (id varchar(50) PRIMARY KEY NOT NULL, text text, priority integer, version integer);",
+		"INSERT INTO todos (id, text, priority, version) VALUES ('1', 'first', 1, 1);",
+	}); err != nil {
+		t.Fatal("could not create table:", err)
+	}
+	_ = s.Close()
+
+	m := Init(true)
+	m.SetSchema(versionSchema{})
+	m.SetHooks(&model.CrudHooks{
+		Update: func(ctx context.Context, dbAlias, col string, req *model.UpdateRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Batch: func(ctx context.Context, dbAlias string, req *model.BatchRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Stage: func(ctx context.Context, intent *model.EventIntent, err error) {},
+	}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := m.SetConfig("project", config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: path}}); err != nil {
+		t.Fatal("could not set config:", err)
+	}
+
+	ctx := context.Background()
+	update := func(find, update map[string]interface{}) error {
+		return m.Update(ctx, "sqlite", "project", "todos", &model.UpdateRequest{Find: find, Update: update, Operation: utils.All})
+	}
+	version := func() int64 {
+		result, _, err := m.Read(ctx, "sqlite", "project", "todos", &model.ReadRequest{Find: map[string]interface{}{"id": "1"}, Operation: utils.One})
+		if err != nil {
+			t.Fatal("could not read:", err)
+		}
+		return result.(map[string]interface{})["version"].(int64)
+	}
+
+	// The expected version can be provided in the find clause
+	if err := update(map[string]interface{}{"id": "1", "version": float64(1)}, map[string]interface{}{"$set": map[string]interface{}{"text": "second"}}); err != nil {
+		t.Fatal("could not update:", err)
+	}
+	if v := version(); v != 2 {
+		t.Errorf("got version %d; wanted 2", v)
+	}
+
+	// Updates expecting an older version are rejected
+	if err := update(map[string]interface{}{"id": "1", "version": float64(1)}, map[string]interface{}{"$set": map[string]interface{}{"text": "third"}}); err != utils.ErrVersionConflict {
+		t.Errorf("got error %v; wanted a version conflict", err)
+	}
+
+	// The expected version can be provided along with the document being set
+	if err := update(map[string]interface{}{"id": "1"}, map[string]interface{}{
+		"$set": map[string]interface{}{"text": "third", "version": float64(2)},
+		"$inc": map[string]interface{}{"priority": 1},
+	}); err != nil {
+		t.Fatal("could not update:", err)
+	}
+	if v := version(); v != 3 {
+		t.Errorf("got version %d; wanted 3", v)
+	}
+
+	// Updates which don't expect any version just bump it
+	if err := update(map[string]interface{}{"id": "1"}, map[string]interface{}{"$set": map[string]interface{}{"text": "fourth"}}); err != nil {
+		t.Fatal("could not update:", err)
+	}
+	if v := version(); v != 4 {
+		t.Errorf("got version %d; wanted 4", v)
+	}
+
+	// The updates of a batch are versioned as well and a conflict rolls back the whole batch
+	batch := func(expected float64, text string) error {
+		return m.Batch(ctx, "sqlite", "project", &model.BatchRequest{Requests: []model.AllRequest{
+			{Type: string(utils.Update), Col: "todos", Operation: utils.All, Find: map[string]interface{}{"id": "1"}, Update: map[string]interface{}{"$set": map[string]interface{}{"priority": 5}}},
+			{Type: string(utils.Update), Col: "todos", Operation: utils.All, Find: map[string]interface{}{"id": "1", "version": expected}, Update: map[string]interface{}{"$set": map[string]interface{}{"text": text}}},
+		}})
+	}
+	if err := batch(5, "fifth"); err != nil {
+		t.Fatal("could not perform batch:", err)
+	}
+	if v := version(); v != 6 {
+		t.Errorf("got version %d; wanted 6", v)
+	}
+	err = batch(5, "sixth")
+	if batchErr, ok := err.(*utils.BatchError); !ok || batchErr.Index != 1 || batchErr.Err != utils.ErrVersionConflict {
+		t.Errorf("got error %v; wanted a version conflict for the second request", err)
+	}
+	if v := version(); v != 6 {
+		t.Errorf("got version %d after the conflicting batch; wanted 6", v)
+	}
+}
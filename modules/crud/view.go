@@ -0,0 +1,46 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// isView checks if the collection is backed by a view. It must be called with the lock held
+func (m *Module) isView(dbAlias, col string) bool {
+	return m.views[strings.TrimPrefix(dbAlias, "sql-")][col]
+}
+
+// IsView checks if the collection is backed by a view
+func (m *Module) IsView(dbAlias, col string) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.isView(dbAlias, col)
+}
+
+// checkWritable returns an error if the collection is a view since views are read only. It must be called with the
+// lock held
+func (m *Module) checkWritable(dbAlias, col string) error {
+	if m.isView(dbAlias, col) {
+		return fmt.Errorf("collection (%s) is a view and cannot be written to", col)
+	}
+	return nil
+}
+
+// SetView creates (or replaces) the mongo view backing a collection
+func (m *Module) SetView(ctx context.Context, dbAlias, project, col, source string, pipeline []interface{}) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	crud, err := m.getCrudBlock(dbAlias)
+	if err != nil {
+		return err
+	}
+
+	if err := crud.IsClientSafe(); err != nil {
+		return err
+	}
+
+	return crud.SetView(ctx, project, col, source, pipeline)
+}
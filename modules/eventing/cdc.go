@@ -0,0 +1,229 @@
+package eventing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/segmentio/ksuid"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// changeDedupWindow is the duration for which the events logged by space cloud are checked while de-duplicating
+// a captured change
+const changeDedupWindow = time.Minute
+
+// syncChangeFeeds starts watching the changes of the databases with change data capture enabled and stops
+// watching the ones which got disabled. Only a single node of the cluster watches the changes
+func (m *Module) syncChangeFeeds() {
+	m.lock.RLock()
+	project := m.project
+	enabled := m.config.Enabled
+	m.lock.RUnlock()
+
+	wanted := map[string]string{}
+	if start, _ := m.syncMan.GetAssignedTokens(); enabled && start == 0 {
+		for _, dbAlias := range m.crud.GetChangeFeeds() {
+			wanted[project+":"+dbAlias] = dbAlias
+		}
+	}
+
+	m.feedLock.Lock()
+	defer m.feedLock.Unlock()
+
+	// Stop the feeds which are no longer required
+	for key, cancel := range m.changeFeeds {
+		if _, p := wanted[key]; !p {
+			cancel()
+			delete(m.changeFeeds, key)
+		}
+	}
+
+	// Start the new feeds
+	for key, dbAlias := range wanted {
+		if _, p := m.changeFeeds[key]; p {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		m.changeFeeds[key] = cancel
+		go m.watchChanges(ctx, key, project, dbAlias)
+	}
+}
+
+// watchChanges processes the changes of a database till the feed is stopped. A failed feed gets restarted by the
+// next sync
+func (m *Module) watchChanges(ctx context.Context, key, project, dbAlias string) {
+	err := m.crud.WatchChanges(ctx, dbAlias, project, func(change *model.DatabaseChange) error {
+		return m.processDatabaseChange(ctx, project, dbAlias, change)
+	})
+
+	// Nothing to do if the feed was stopped
+	if ctx.Err() != nil {
+		return
+	}
+
+	log.Println("Eventing module could not watch the changes of", dbAlias, "-", err)
+
+	m.feedLock.Lock()
+	delete(m.changeFeeds, key)
+	m.feedLock.Unlock()
+}
+
+// processDatabaseChange stages the events for a captured change unless space cloud has already logged them. An
+// error is returned if the events could not be staged, so that the change gets captured again
+func (m *Module) processDatabaseChange(ctx context.Context, project, dbAlias string, change *model.DatabaseChange) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if !m.config.Enabled || m.project != project {
+		return nil
+	}
+
+	// Changes made to the events themselves are of no interest
+	if dbAlias == m.config.DBType && change.Col == m.config.Col {
+		return nil
+	}
+
+	rules := m.getMatchingRules(change.Type, map[string]string{"col": change.Col, "db": dbAlias})
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// The find clause is generated the same way as for the requests made via space cloud
+	obj, isFind := change.Find, true
+	if change.Type == utils.EventDBCreate {
+		obj, isFind = change.Doc, false
+	}
+	find, possible := m.schema.CheckIfEventingIsPossible(dbAlias, change.Col, obj, isFind)
+	if !possible {
+		find = change.Find
+	}
+
+	isLogged, err := m.isChangeLogged(ctx, dbAlias, change, find)
+	if err != nil {
+		return fmt.Errorf("could not check if the change was logged - %v", err)
+	}
+	if isLogged {
+		return nil
+	}
+
+	payload := model.DatabaseEventMessage{DBType: dbAlias, Col: change.Col, Doc: change.Doc, Find: find}
+
+	// Create the meta information
+	token := rand.Intn(utils.MaxEventTokens)
+	batchID := ksuid.New().String()
+
+	eventDocs := make([]*model.EventDocument, len(rules))
+	for i, rule := range rules {
+		eventDocs[i] = m.generateQueueEventRequest(token, rule.Retries, batchID, utils.EventStatusStaged, rule.Url,
+			&model.QueueEventRequest{Type: change.Type, Payload: payload})
+	}
+
+	// Persist the events
+	createRequest := &model.CreateRequest{Document: convertToArray(eventDocs), Operation: utils.All}
+	if err := m.crud.InternalCreate(ctx, m.config.DBType, m.project, m.config.Col, createRequest); err != nil {
+		return fmt.Errorf("could not log the captured change - %v", err)
+	}
+
+	// Broadcast the event so the concerned worker can process it immediately
+	m.transmitEvents(token, eventDocs)
+	return nil
+}
+
+// isChangeLogged checks if space cloud has logged an event for the change. The events are looked up by the key of the
+// changed document. An event logged by space cloud accounts for a single captured change only
+func (m *Module) isChangeLogged(ctx context.Context, dbAlias string, change *model.DatabaseChange, find map[string]interface{}) (bool, error) {
+	now := time.Now().UTC()
+	since := now.Add(-changeDedupWindow).UnixNano() / int64(time.Millisecond)
+
+	readRequest := &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{
+		"change_key":      changeKey(dbAlias, change.Col, change.Type, find),
+		"status":          map[string]interface{}{"$ne": utils.EventStatusCancelled},
+		"event_timestamp": map[string]interface{}{"$gte": since},
+	}}
+	results, err := m.crud.InternalRead(ctx, m.config.DBType, m.project, m.config.Col, readRequest)
+	if err != nil {
+		return false, err
+	}
+
+	m.feedLock.Lock()
+	defer m.feedLock.Unlock()
+
+	// Forget the events which are out of the window
+	for key, timestamp := range m.consumedEvents {
+		if timestamp < since {
+			delete(m.consumedEvents, key)
+		}
+	}
+
+	for _, temp := range results.([]interface{}) {
+		eventDoc := new(model.EventDocument)
+		if err := mapstructure.Decode(temp, eventDoc); err != nil {
+			continue
+		}
+
+		// The events of a batch are logged per rule for the same document
+		if _, p := m.consumedEvents[eventDoc.BatchID]; p {
+			continue
+		}
+		m.consumedEvents[eventDoc.BatchID] = eventDoc.EventTimestamp
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isCaptured checks if the changes of the database are captured
+func (m *Module) isCaptured(dbAlias string) bool {
+	dbAlias = strings.TrimPrefix(dbAlias, "sql-")
+	for _, alias := range m.crud.GetChangeFeeds() {
+		if alias == dbAlias {
+			return true
+		}
+	}
+	return false
+}
+
+// setChangeKeys sets the key of the document changed on the events logged by space cloud if the changes of the
+// database are captured
+func (m *Module) setChangeKeys(dbAlias, col, eventType string, find map[string]interface{}, eventDocs []*model.EventDocument) {
+	if !m.isCaptured(dbAlias) {
+		return
+	}
+
+	key := changeKey(dbAlias, col, eventType, find)
+	for _, eventDoc := range eventDocs {
+		eventDoc.ChangeKey = key
+	}
+}
+
+// changeKey identifies the document changed by an operation. The find clause is the one which identifies a single
+// document (by its primary key or a unique index)
+func changeKey(dbAlias, col, eventType string, find map[string]interface{}) string {
+	// The values are normalised so that the values reported by the database match the ones provided to space cloud
+	data, _ := json.Marshal(normaliseValue(find))
+	return strings.Join([]string{strings.TrimPrefix(dbAlias, "sql-"), col, eventType, string(data)}, ":")
+}
+
+// normaliseValue brings the value to the form it would have after being logged so that the values reported by the
+// database can be compared with the ones logged
+func normaliseValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalised interface{}
+	if err := json.Unmarshal(data, &normalised); err != nil {
+		return value
+	}
+	return normalised
+}
@@ -72,6 +72,14 @@ func (m *Module) HookDBBatchIntent(ctx context.Context, dbType string, req *mode
 			eventDocs = append(eventDocs, docs...)
 
 		case string(utils.Update):
+			if r.Operation == utils.Upsert {
+				docs, ok := m.processUpsertHook(ctx, token, batchID, dbType, r.Col, r.Find, r.Update)
+				if ok {
+					eventDocs = append(eventDocs, docs...)
+				}
+				continue
+			}
+
 			docs, ok := m.processUpdateDeleteHook(token, utils.EventDBUpdate, batchID, dbType, r.Col, r.Find)
 			if ok {
 				eventDocs = append(eventDocs, docs...)
@@ -112,6 +120,11 @@ func (m *Module) HookDBUpdateIntent(ctx context.Context, dbType, col string, req
 		return &model.EventIntent{Invalid: true}, nil
 	}
 
+	// An upsert results in a create event if the document does not exist yet
+	if req.Operation == utils.Upsert {
+		return m.hookDBUpsertIntent(ctx, dbType, col, req.Find, req.Update)
+	}
+
 	return m.hookDBUpdateDeleteIntent(ctx, utils.EventDBUpdate, dbType, col, req.Find)
 }
 
@@ -148,6 +161,26 @@ func (m *Module) hookDBUpdateDeleteIntent(ctx context.Context, eventType, dbType
 	return &model.EventIntent{Invalid: true}, nil
 }
 
+// hookDBUpsertIntent is used as the hook for upsert events
+func (m *Module) hookDBUpsertIntent(ctx context.Context, dbType, col string, find, update map[string]interface{}) (*model.EventIntent, error) {
+	// Create a unique batch id and token
+	batchID := ksuid.New().String()
+	token := rand.Intn(utils.MaxEventTokens)
+
+	eventDocs, ok := m.processUpsertHook(ctx, token, batchID, dbType, col, find, update)
+	if ok {
+		// Persist the event intent
+		createRequest := &model.CreateRequest{Document: convertToArray(eventDocs), Operation: utils.All}
+		if err := m.crud.InternalCreate(ctx, m.config.DBType, m.project, m.config.Col, createRequest); err != nil {
+			return nil, errors.New("eventing module couldn't log the request - " + err.Error())
+		}
+
+		return &model.EventIntent{BatchID: batchID, Token: token, Docs: eventDocs}, nil
+	}
+
+	return &model.EventIntent{Invalid: true}, nil
+}
+
 // HookStage stages the event so that it can be processed
 func (m *Module) HookStage(ctx context.Context, intent *model.EventIntent, err error) {
 	m.lock.RLock()
@@ -196,7 +229,7 @@ func (m *Module) HookStage(ctx context.Context, intent *model.EventIntent, err e
 				Operation: utils.One,
 			}
 
-			result, err := m.crud.Read(ctx, dbEvent.DBType, m.project, dbEvent.Col, req)
+			result, err := m.crud.InternalRead(ctx, dbEvent.DBType, m.project, dbEvent.Col, req)
 			if err != nil {
 				log.Println("Eventing Staging Error:", err)
 				continue
@@ -249,18 +282,38 @@ func (m *Module) processCreateDocs(token int, batchID, dbAlias, col string, rows
 		}
 
 		// Iterate over all rules
-		for _, rule := range rules {
-			eventDocs = append(eventDocs, m.generateQueueEventRequest(token, rule.Retries,
+		docEvents := make([]*model.EventDocument, len(rules))
+		for i, rule := range rules {
+			docEvents[i] = m.generateQueueEventRequest(token, rule.Retries,
 				batchID, utils.EventStatusIntent, rule.Url, &model.QueueEventRequest{
 					Type:    utils.EventDBCreate,
 					Payload: model.DatabaseEventMessage{DBType: dbAlias, Col: col, Doc: doc, Find: findForCreate},
-				}))
+				})
 		}
+		m.setChangeKeys(dbAlias, col, utils.EventDBCreate, findForCreate, docEvents)
+		eventDocs = append(eventDocs, docEvents...)
 	}
 
 	return eventDocs
 }
 
+// processUpsertHook generates an update event if a document matches the find clause of the upsert. A create
+// event is generated otherwise since the upsert will end up inserting the document
+func (m *Module) processUpsertHook(ctx context.Context, token int, batchID, dbType, col string, find, update map[string]interface{}) ([]*model.EventDocument, bool) {
+	result, err := m.crud.InternalRead(ctx, dbType, m.project, col, &model.ReadRequest{Find: find, Operation: utils.Count})
+	if err != nil {
+		log.Println("Eventing Error: could not check if the document to be upserted exists -", err)
+		return nil, false
+	}
+
+	if count, ok := result.(int64); ok && count > 0 {
+		return m.processUpdateDeleteHook(token, utils.EventDBUpdate, batchID, dbType, col, find)
+	}
+
+	docs := m.processCreateDocs(token, batchID, dbType, col, []interface{}{utils.GenerateUpsertDoc(find, update)})
+	return docs, len(docs) > 0
+}
+
 func (m *Module) processUpdateDeleteHook(token int, eventType, batchID, dbType, col string, find map[string]interface{}) ([]*model.EventDocument, bool) {
 	// Get event listeners
 	rules := m.getMatchingRules(eventType, map[string]string{"col": col, "db": dbType})
@@ -290,6 +343,7 @@ func (m *Module) processUpdateDeleteHook(token int, eventType, batchID, dbType,
 	if len(eventDocs) == 0 {
 		return nil, false
 	}
+	m.setChangeKeys(dbType, col, eventType, findForUpdate, eventDocs)
 
 	return eventDocs, true
 }
@@ -1,6 +1,7 @@
 package eventing
 
 import (
+	"context"
 	"errors"
 	"sync"
 
@@ -25,6 +26,12 @@ type Module struct {
 	// Atomic maps to handle events being processed
 	processingEvents sync.Map
 
+	// The feeds of the databases whose changes are captured and the events logged by space cloud which were
+	// matched with a captured change
+	feedLock       sync.Mutex
+	changeFeeds    map[string]context.CancelFunc
+	consumedEvents map[string]int64
+
 	// Variables defined during initialisation
 	auth      *auth.Module
 	crud      *crud.Module
@@ -47,11 +54,15 @@ func New(auth *auth.Module, crud *crud.Module, schema *schema.Schema, functions
 		syncMan:   syncMan,
 		fileStore: file,
 		config:    &config.Eventing{Enabled: false, InternalRules: map[string]config.EventingRule{}},
+
+		changeFeeds:    map[string]context.CancelFunc{},
+		consumedEvents: map[string]int64{},
 	}
 
 	// Start the internal processes
 	go m.routineProcessIntents()
 	go m.routineProcessStaged()
+	go m.routineProcessChanges()
 
 	return m
 }
@@ -23,9 +23,8 @@ func (m *Module) processIntents(t *time.Time) {
 	dbType, col := m.config.DBType, m.config.Col
 	m.lock.RUnlock()
 
-	// Create a context with 5 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	// Create a context of execution
+	ctx := context.Background()
 
 	start, end := m.syncMan.GetAssignedTokens()
 
@@ -37,7 +36,7 @@ func (m *Module) processIntents(t *time.Time) {
 		},
 	}}
 
-	results, err := m.crud.Read(ctx, dbType, project, col, &readRequest)
+	results, err := m.crud.InternalRead(ctx, dbType, project, col, &readRequest)
 	if err != nil {
 		log.Println("Eventing intent routine error:", err)
 		return
@@ -66,9 +65,8 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
-	// Create a context with 5 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	// Create a context of execution
+	ctx := context.Background()
 
 	// Get the eventID
 	eventID := eventDoc.ID
@@ -82,7 +80,7 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 
 		// Check if document exists in database
 		readRequest := &model.ReadRequest{Operation: utils.One, Find: createEvent.Find.(map[string]interface{})}
-		if _, err := m.crud.Read(ctx, createEvent.DBType, m.project, createEvent.Col, readRequest); err != nil {
+		if _, err := m.crud.InternalRead(ctx, createEvent.DBType, m.project, createEvent.Col, readRequest); err != nil {
 
 			// Mark event as cancelled if it document doesn't exist
 			if err := m.crud.InternalUpdate(ctx, m.config.DBType, m.project, m.config.Col, m.generateCancelEventRequest(eventID)); err != nil {
@@ -109,7 +107,7 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 		// Get the document from the database
 		timestamp := time.Now().UTC().UnixNano() / int64(time.Millisecond)
 		readRequest := &model.ReadRequest{Operation: utils.One, Find: updateEvent.Find.(map[string]interface{})}
-		result, err := m.crud.Read(ctx, updateEvent.DBType, m.project, updateEvent.Col, readRequest)
+		result, err := m.crud.InternalRead(ctx, updateEvent.DBType, m.project, updateEvent.Col, readRequest)
 		if err != nil {
 			// Do nothing if there is an error while reading
 			return
@@ -142,7 +140,7 @@ func (m *Module) processIntent(eventDoc *model.EventDocument) {
 
 		// Check if document exists in database
 		readRequest := &model.ReadRequest{Operation: utils.One, Find: deleteEvent.Find.(map[string]interface{})}
-		if _, err := m.crud.Read(ctx, deleteEvent.DBType, m.project, deleteEvent.Col, readRequest); err == nil {
+		if _, err := m.crud.InternalRead(ctx, deleteEvent.DBType, m.project, deleteEvent.Col, readRequest); err == nil {
 
 			// Mark the event as cancelled if the document still exists
 			_ = m.crud.InternalUpdate(ctx, m.config.DBType, m.project, m.config.Col, m.generateCancelEventRequest(eventID))
@@ -37,7 +37,7 @@ func (m *Module) processStagedEvents(t *time.Time) {
 		},
 	}}
 
-	results, err := m.crud.Read(ctx, dbType, project, col, &readRequest)
+	results, err := m.crud.InternalRead(ctx, dbType, project, col, &readRequest)
 	if err != nil {
 		log.Println("Eventing stage routine error:", err)
 		return
@@ -61,9 +61,8 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 
-	// Create a context with 5 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	// Create a context of execution
+	ctx := context.Background()
 
 	// Return if the event is already being processed
 	if _, loaded := m.processingEvents.LoadOrStore(eventDoc.ID, true); loaded {
@@ -73,10 +72,6 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 	// Delete the event from the processing list without fail
 	defer m.processingEvents.Delete(eventDoc.ID)
 
-	// Call the function to process the event
-	ctxLocal, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
 	// Create a variable to track retries
 	retries := 0
 
@@ -101,8 +96,9 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 			return
 		}
 
+		// Call the function to process the event. Only the call to the service is bounded by the timeout
 		var eventResponse model.EventResponse
-		err = m.syncMan.MakeHTTPRequest(ctxLocal, "POST", eventDoc.Url, internalToken, scToken, cloudEvent, &eventResponse)
+		err = m.callService(ctx, eventDoc.Url, internalToken, scToken, cloudEvent, &eventResponse)
 		if err == nil {
 			var eventRequests []*model.QueueEventRequest
 
@@ -121,7 +117,7 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 				}
 			}
 
-			_ = m.crud.InternalUpdate(ctxLocal, m.config.DBType, m.project, m.config.Col, m.generateProcessedEventRequest(eventDoc.ID))
+			_ = m.crud.InternalUpdate(ctx, m.config.DBType, m.project, m.config.Col, m.generateProcessedEventRequest(eventDoc.ID))
 			return
 		}
 
@@ -142,3 +138,11 @@ func (m *Module) processStagedEvent(eventDoc *model.EventDocument) {
 		log.Println("Eventing staged event handler could not update event doc:", err)
 	}
 }
+
+// callService makes the request to the service of an event rule. Each attempt is given 10 seconds
+func (m *Module) callService(ctx context.Context, url, token, scToken string, params, vPtr interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return m.syncMan.MakeHTTPRequest(ctx, "POST", url, token, scToken, params, vPtr)
+}
@@ -17,3 +17,10 @@ func (m *Module) routineProcessStaged() {
 		m.processStagedEvents(&t)
 	}
 }
+
+func (m *Module) routineProcessChanges() {
+	ticker := time.NewTicker(10 * time.Second)
+	for range ticker.C {
+		m.syncChangeFeeds()
+	}
+}
@@ -38,8 +38,9 @@ func generateEventRules(crudConfig config.Crud, project, url string) []config.Ev
 			// Iterate over all connections
 			for col, colStub := range dbStub.Collections {
 
-				// Check if realtime mode is enabled
-				if colStub.IsRealTimeEnabled {
+				// Check if realtime mode is enabled. Cached collections need the events as well to invalidate the
+				// caches of the other nodes
+				if colStub.IsRealTimeEnabled || colStub.Cache != nil {
 
 					// Add a new event for each db event type
 					for _, eventType := range dbEvents {
@@ -15,7 +15,13 @@ import (
 // Subscribe performs the realtime subscribe operation.
 func (m *Module) Subscribe(ctx context.Context, clientID string, data *model.RealtimeRequest, sendFeed SendFeed) ([]*model.FeedData, error) {
 
-	readReq := &model.ReadRequest{Find: data.Where, Operation: utils.All}
+	readReq := &model.ReadRequest{Find: data.Where, Operation: utils.All, Options: &model.ReadOptions{Join: data.Options.Join, WithDeleted: data.Options.WithDeleted}}
+
+	// The live query must not match the soft deleted documents either
+	where := data.Where
+	if !data.Options.WithDeleted {
+		where = m.crud.ExcludeDeleted(data.DBType, data.Group, where)
+	}
 
 	// Check if the user is authorised to make the request
 	actions, _, err := m.auth.IsReadOpAuthorised(ctx, data.Project, data.DBType, data.Group, data.Token, readReq)
@@ -24,14 +30,11 @@ func (m *Module) Subscribe(ctx context.Context, clientID string, data *model.Rea
 	}
 
 	if data.Options.SkipInitial {
-		m.AddLiveQuery(data.ID, data.Project, data.DBType, data.Group, clientID, data.Where, actions, sendFeed)
+		m.AddLiveQuery(data.ID, data.Project, data.DBType, data.Group, clientID, where, actions, sendFeed)
 		return []*model.FeedData{}, nil
 	}
 
-	ctx2, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	result, err := m.crud.Read(ctx2, data.DBType, data.Project, data.Group, readReq)
+	result, _, err := m.crud.Read(ctx, data.DBType, data.Project, data.Group, readReq)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +63,7 @@ func (m *Module) Subscribe(ctx context.Context, clientID string, data *model.Rea
 	}
 
 	// Add the live query
-	m.AddLiveQuery(data.ID, data.Project, data.DBType, data.Group, clientID, data.Where, actions, sendFeed)
+	m.AddLiveQuery(data.ID, data.Project, data.DBType, data.Group, clientID, where, actions, sendFeed)
 	return feedData, nil
 }
 
@@ -132,6 +135,9 @@ func (m *Module) ProcessRealtimeRequests(eventDoc *model.CloudEventPayload) erro
 		return err
 	}
 
+	// The reads cached by this node are stale now
+	m.crud.InvalidateCache(dbEvent.DBType, dbEvent.Col)
+
 	t, _ := time.Parse(time.RFC3339, eventDoc.Time)
 	feedData := &model.FeedData{
 		Type:      eventingToRealtimeEvent(eventDoc.Type),
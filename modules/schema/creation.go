@@ -3,6 +3,7 @@ package schema
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/spaceuptech/space-cloud/config"
@@ -18,24 +19,36 @@ type creationModule struct {
 
 // SchemaCreation creates or alters tables of sql
 func (s *Schema) SchemaCreation(ctx context.Context, dbAlias, tableName, project string, parsedSchema schemaType) error {
-	dbType, err := s.crud.GetDBType(dbAlias)
+	queries, err := s.planSchemaCreation(ctx, dbAlias, tableName, project, parsedSchema)
 	if err != nil {
 		return err
 	}
+	return s.executeQueries(ctx, dbAlias, project, queries)
+}
 
-	// Return gracefully if db type is mongo
-	if dbType == string(utils.Mongo) {
-		return nil
+// planSchemaCreation returns the queries which create or alter the table (along with the tables it references) as
+// per the schema without running them
+func (s *Schema) planSchemaCreation(ctx context.Context, dbAlias, tableName, project string, parsedSchema schemaType) ([]string, error) {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.crud.CreateProjectIfNotExists(ctx, project, dbAlias); err != nil {
-		return err
+	// Return gracefully if db type is mongo
+	if dbType == string(utils.Mongo) {
+		return nil, nil
 	}
 
 	currentSchema, _ := s.Inspector(ctx, dbAlias, project, tableName)
+	return s.generateCreationQueries(ctx, dbAlias, tableName, project, parsedSchema, currentSchema)
+}
 
-	queries, err := s.generateCreationQueries(ctx, dbAlias, tableName, project, parsedSchema, currentSchema)
-	if err != nil {
+func (s *Schema) executeQueries(ctx context.Context, dbAlias, project string, queries []string) error {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	if err := s.crud.CreateProjectIfNotExists(ctx, project, dbAlias); err != nil {
 		return err
 	}
 	return s.crud.RawBatch(ctx, dbAlias, queries)
@@ -55,6 +68,14 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 	realSchema := parsedSchema[dbAlias]
 	batchedQueries := []string{}
 
+	// The tables referenced by foreign keys need to be created before the table
+	jointQueries := []string{}
+	jointTables := map[string]bool{}
+
+	// SQLite does not support schemas. Hence tables are never scoped by project
+	isSQLite := utils.DBType(dbType) == utils.SQLite
+	removeProjectScope := s.removeProjectScope || isSQLite
+
 	realTableName := tableName
 	realTableInfo, p1 := realSchema[realTableName]
 	if !p1 {
@@ -69,7 +90,7 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 	currentTableInfo, ok := currentSchema[realTableName]
 	if !ok {
 		// create table with primary key
-		query, err := addNewTable(project, dbAlias, realTableName, realTableInfo, s.removeProjectScope)
+		query, err := addNewTable(project, dbType, realTableName, realTableInfo, removeProjectScope)
 		if err != nil {
 			return nil, err
 		}
@@ -82,31 +103,73 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 				IsList:              realColumnInfo.IsList,
 				Kind:                realColumnInfo.Kind,
 				IsPrimary:           realColumnInfo.IsPrimary,
+				PrimaryKeyInfo:      realColumnInfo.PrimaryKeyInfo,
+				EnumInfo:            realColumnInfo.EnumInfo,
+				Validators:          realColumnInfo.Validators,
 				nestedObject:        realColumnInfo.nestedObject,
 			}
+			if isSQLite {
+				// SQLite tables are created along with their default and foreign key constraints
+				temp.IsForeign, temp.JointTable = realColumnInfo.IsForeign, realColumnInfo.JointTable
+				temp.IsDefault, temp.Default = realColumnInfo.IsDefault, realColumnInfo.Default
+			}
 			currentTableInfo[realColumnName] = &temp
 		}
 	}
 
-	for realColumnName, realColumnInfo := range realTableInfo {
+	// Keys spanning multiple fields are modified at the table level. The fields which are a part of them are
+	// treated as regular fields while modifying the columns
+	realKeys, err := getTableKeys(realTableInfo)
+	if err != nil {
+		return nil, err
+	}
+	currentKeys, err := getTableKeys(currentTableInfo)
+	if err != nil {
+		return nil, err
+	}
+	realSingleKeyInfo := realKeys.singleKeyFields(realTableInfo)
+	currentSingleKeyInfo := currentKeys.singleKeyFields(currentTableInfo)
+
+	realForeignKeys, currentForeignKeys := realKeys.foreignKeySignatures(), currentKeys.foreignKeySignatures()
+	isPrimaryKeyModified := realKeys.primaryKeySignature() != currentKeys.primaryKeySignature()
+	if isSQLite && (isPrimaryKeyModified || !reflect.DeepEqual(sortedKeys(realForeignKeys), sortedKeys(currentForeignKeys))) {
+		return nil, fmt.Errorf("sqlite does not support modifying the keys of an existing table (%s) - recreate the table instead", realTableName)
+	}
+
+	// Drop the keys before modifying the columns they are made on
+	for _, signature := range sortedKeys(currentForeignKeys) {
+		if _, p := realForeignKeys[signature]; !p {
+			batchedQueries = append(batchedQueries, removeCompositeForeignKey(dbType, project, realTableName, currentForeignKeys[signature], removeProjectScope)...)
+		}
+	}
+	if isPrimaryKeyModified && len(currentKeys.primary) > 0 {
+		batchedQueries = append(batchedQueries, removeCompositePrimaryKey(dbType, project, realTableName, currentKeys.primary, removeProjectScope))
+	}
+
+	for _, realColumnName := range sortedFieldNames(realSingleKeyInfo) {
+		realColumnInfo := realSingleKeyInfo[realColumnName]
 		// Ignore the field if its linked
 		if realColumnInfo.IsLinked {
 			continue
 		}
-		if err := checkErrors(realColumnInfo); err != nil {
+		if err := checkErrors(realTableInfo[realColumnName]); err != nil {
 			return nil, err
 		}
 
 		// Create the joint table first
-		if realColumnInfo.IsForeign {
-			if _, p := currentSchema[realColumnInfo.JointTable.Table]; !p {
-				if err := s.SchemaCreation(ctx, dbAlias, realColumnInfo.JointTable.Table, project, parsedSchema); err != nil {
+		if realTableInfo[realColumnName].IsForeign {
+			jointTable := realColumnInfo.JointTable.Table
+			if _, p := currentSchema[jointTable]; !p && !jointTables[jointTable] {
+				jointTables[jointTable] = true
+				queries, err := s.planSchemaCreation(ctx, dbAlias, jointTable, project, parsedSchema)
+				if err != nil {
 					return nil, err
 				}
+				jointQueries = append(jointQueries, queries...)
 			}
 		}
-		currentColumnInfo, ok := currentTableInfo[realColumnName]
-		columnType, err := getSQLType(dbType, realColumnInfo.Kind)
+		currentColumnInfo, ok := currentSingleKeyInfo[realColumnName]
+		columnType, err := getColumnType(dbType, realColumnInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -119,7 +182,7 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 			currentColumnInfo:  currentColumnInfo,
 			realColumnInfo:     realColumnInfo,
 			schemaModule:       s,
-			removeProjectScope: s.removeProjectScope,
+			removeProjectScope: removeProjectScope,
 		}
 
 		if !ok || currentColumnInfo.IsLinked {
@@ -132,14 +195,21 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 
 		} else {
 			if !realColumnInfo.IsLinked {
-				if c.realColumnInfo.Kind != c.currentColumnInfo.Kind {
+				if isColumnTypeModified(dbType, c.realColumnInfo, c.currentColumnInfo) {
+					if isSQLite {
+						return nil, errSQLiteAlterColumn(realTableName, realColumnName)
+					}
+
 					// for changing the type of column, drop the column then add new column
 					queries := c.modifyColumnType(dbType)
 
 					batchedQueries = append(batchedQueries, queries...)
 				} else {
 					// make changes according to the changes in directives
-					queries := c.modifyColumn()
+					queries := c.modifyColumn(dbType)
+					if isSQLite && len(queries) > 0 {
+						return nil, errSQLiteAlterColumn(realTableName, realColumnName)
+					}
 
 					batchedQueries = append(batchedQueries, queries...)
 				}
@@ -154,8 +224,15 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 			continue
 		}
 		for currentFieldKey, currentFieldStruct := range currentColValue {
+			if currentColName == realTableName {
+				currentFieldStruct = currentSingleKeyInfo[currentFieldKey]
+			}
 			realField, ok := realColValue[currentFieldKey]
 			if !ok || realField.IsLinked {
+				if isSQLite {
+					return nil, errSQLiteAlterColumn(currentColName, currentFieldKey)
+				}
+
 				// remove field from current tabel
 				c := creationModule{
 					dbAlias:            dbAlias,
@@ -163,16 +240,29 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 					TableName:          currentColName,
 					ColumnName:         currentFieldKey,
 					currentColumnInfo:  currentFieldStruct,
-					removeProjectScope: s.removeProjectScope,
+					removeProjectScope: removeProjectScope,
 				}
 				if c.currentColumnInfo.IsForeign {
 					batchedQueries = append(batchedQueries, c.removeForeignKey()...)
 				}
+				if checkExpression(dbType, c.currentColumnInfo) != "" {
+					batchedQueries = append(batchedQueries, c.removeCheckConstraint())
+				}
 				batchedQueries = append(batchedQueries, c.removeColumn())
 			}
 		}
 	}
 
+	// Add the keys once the columns they are made on exist
+	if isPrimaryKeyModified && len(realKeys.primary) > 0 {
+		batchedQueries = append(batchedQueries, addCompositePrimaryKey(dbType, project, realTableName, realKeys.primary, removeProjectScope))
+	}
+	for _, signature := range sortedKeys(realForeignKeys) {
+		if _, p := currentForeignKeys[signature]; !p {
+			batchedQueries = append(batchedQueries, addCompositeForeignKey(project, realTableName, realForeignKeys[signature], removeProjectScope))
+		}
+	}
+
 	realIndexMap, err := getRealIndexMap(realTableInfo)
 	if err != nil {
 		return nil, err
@@ -183,28 +273,31 @@ func (s *Schema) generateCreationQueries(ctx context.Context, dbAlias, tableName
 	}
 	for indexName, fields := range realIndexMap {
 		if _, ok := currentIndexMap[indexName]; !ok {
-			batchedQueries = append(batchedQueries, addIndex(dbType, project, tableName, indexName, fields.IsIndexUnique, s.removeProjectScope, fields.IndexMap))
+			batchedQueries = append(batchedQueries, addIndex(dbType, project, tableName, indexName, fields.IsIndexUnique, removeProjectScope, fields.IndexMap))
 			continue
 		}
 		if !reflect.DeepEqual(fields.IndexMap, currentIndexMap[indexName].IndexMap) {
-			batchedQueries = append(batchedQueries, removeIndex(dbType, project, tableName, indexName, s.removeProjectScope))
-			batchedQueries = append(batchedQueries, addIndex(dbType, project, tableName, indexName, fields.IsIndexUnique, s.removeProjectScope, fields.IndexMap))
+			batchedQueries = append(batchedQueries, removeIndex(dbType, project, tableName, indexName, removeProjectScope))
+			batchedQueries = append(batchedQueries, addIndex(dbType, project, tableName, indexName, fields.IsIndexUnique, removeProjectScope, fields.IndexMap))
 		}
 	}
 	for indexName, _ := range currentIndexMap {
 		if _, ok := realIndexMap[indexName]; !ok {
-			batchedQueries = append(batchedQueries, removeIndex(dbType, project, tableName, indexName, s.removeProjectScope))
+			batchedQueries = append(batchedQueries, removeIndex(dbType, project, tableName, indexName, removeProjectScope))
 		}
 	}
 
-	return batchedQueries, nil
+	return append(jointQueries, batchedQueries...), nil
 }
 
-// SchemaModifyAll modifies all the tables provided
-func (s *Schema) SchemaModifyAll(ctx context.Context, dbAlias, project string, tables map[string]*config.TableRule) error {
+// SchemaModifyAll modifies all the tables provided. The queries applied to each table are recorded as a migration
+// along with the admin who applied them
+func (s *Schema) SchemaModifyAll(ctx context.Context, dbAlias, project, appliedBy string, tables map[string]*config.TableRule) error {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
+	tables = s.withStoredRules(dbAlias, tables)
+
 	crud := config.Crud{}
 	crud[dbAlias] = &config.CrudStub{
 		Enabled:     true,
@@ -215,12 +308,117 @@ func (s *Schema) SchemaModifyAll(ctx context.Context, dbAlias, project string, t
 		return err
 	}
 	for tableName, info := range tables {
-		if info.Schema == "" {
+		// Views are created from their definition once the tables they select from exist
+		if info.Schema == "" || info.View != nil {
+			continue
+		}
+
+		queries, err := s.planSchemaCreation(ctx, dbAlias, tableName, project, parsedSchema)
+		if err != nil {
+			return err
+		}
+		if len(queries) == 0 {
+			continue
+		}
+
+		if err := s.applyMigration(ctx, dbAlias, project, tableName, appliedBy, queries); err != nil {
+			return err
+		}
+	}
+
+	for tableName, info := range tables {
+		if info.View == nil {
+			continue
+		}
+
+		queries, err := s.planViewCreation(ctx, dbAlias, project, tableName, info.View)
+		if err != nil {
+			return err
+		}
+		if len(queries) == 0 {
 			continue
 		}
-		if err := s.SchemaCreation(ctx, dbAlias, tableName, project, parsedSchema); err != nil {
+		if err := s.createView(ctx, dbAlias, project, tableName, appliedBy, info.View, queries); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// PlanSchemaModifyAll returns the queries which would be run by SchemaModifyAll for each table without running them
+func (s *Schema) PlanSchemaModifyAll(ctx context.Context, dbAlias, project string, tables map[string]*config.TableRule) (map[string][]string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	tables = s.withStoredRules(dbAlias, tables)
+
+	crud := config.Crud{}
+	crud[dbAlias] = &config.CrudStub{
+		Enabled:     true,
+		Collections: tables,
+	}
+	parsedSchema, err := s.parser(crud)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := map[string][]string{}
+	for tableName, info := range tables {
+		// Views are created from their definition rather than the schema
+		if info.View != nil {
+			queries, err := s.planViewCreation(ctx, dbAlias, project, tableName, info.View)
+			if err != nil {
+				return nil, err
+			}
+			if len(queries) > 0 {
+				plan[tableName] = queries
+			}
+			continue
+		}
+
+		if info.Schema == "" {
+			continue
+		}
+
+		queries, err := s.planSchemaCreation(ctx, dbAlias, tableName, project, parsedSchema)
+		if err != nil {
+			return nil, err
+		}
+		if len(queries) > 0 {
+			plan[tableName] = queries
+		}
+	}
+	return plan, nil
+}
+
+// withStoredRules fills in the soft delete flag and the view of the tables from the config when the request leaves them
+// out, so that editing the schema of a table doesn't undo them. It must be called with the lock held
+func (s *Schema) withStoredRules(dbAlias string, tables map[string]*config.TableRule) map[string]*config.TableRule {
+	dbStub, p := s.config[dbAlias]
+	if !p || dbStub == nil {
+		return tables
+	}
+
+	merged := make(map[string]*config.TableRule, len(tables))
+	for tableName, info := range tables {
+		stored, p := dbStub.Collections[tableName]
+		if !p || stored == nil {
+			merged[tableName] = info
+			continue
+		}
+
+		rule := *info
+		if !rule.SoftDelete {
+			rule.SoftDelete = stored.SoftDelete
+		}
+		if rule.View == nil {
+			rule.View = stored.View
+		}
+		merged[tableName] = &rule
+	}
+	return merged
+}
+
+func errSQLiteAlterColumn(table, column string) error {
+	return fmt.Errorf("sqlite does not support modifying or dropping existing column (%s) of table (%s) - recreate the table instead", column, table)
+}
@@ -35,6 +35,9 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 	crudSqlServer := crud.Init(false)
 	crudSqlServer.SetConfig("test", config.Crud{"sqlserver": {Type: "sql-sqlserver", Enabled: false}})
 
+	crudSQLite := crud.Init(false)
+	crudSQLite.SetConfig("test", config.Crud{"sqlite": {Type: "sql-sqlite", Enabled: false}})
+
 	tests := []struct {
 		name    string
 		fields  fields
@@ -2167,6 +2170,44 @@ func TestSchema_generateCreationQueries(t *testing.T) {
 			fields:  fields{crud: crudPostgres, project: "test"},
 			wantErr: true,
 		},
+		{
+			name: "sqlite: adding a table with a foreign key constraint declared inline",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  schemaType{"sqlite": schemaCollection{"table1": SchemaFields{"col2": &SchemaFieldType{FieldName: "col2", Kind: TypeID, IsFieldTypeRequired: true, IsForeign: true, JointTable: &TableProperties{Table: "table2", To: "id"}}}, "table2": SchemaFields{"id": &SchemaFieldType{FieldName: "id", Kind: TypeID, IsFieldTypeRequired: true, IsPrimary: true}}}},
+				currentSchema: schemaCollection{"table2": SchemaFields{"id": &SchemaFieldType{FieldName: "id", Kind: TypeID, IsFieldTypeRequired: true, IsPrimary: true}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			want:    []string{"CREATE TABLE table1 (col2 varchar(50) NOT NULL REFERENCES table2 (id) );"},
+			wantErr: false,
+		},
+		{
+			name: "sqlite: adding a new column to an existing table",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  schemaType{"sqlite": schemaCollection{"table1": SchemaFields{"col1": &SchemaFieldType{FieldName: "col1", Kind: typeString}, "col2": &SchemaFieldType{FieldName: "col2", Kind: typeInteger, IsFieldTypeRequired: true, IsDefault: true, Default: 10}}}},
+				currentSchema: schemaCollection{"table1": SchemaFields{"col1": &SchemaFieldType{FieldName: "col1", Kind: typeString}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			want:    []string{"ALTER TABLE table1 ADD COLUMN col2 bigint NOT NULL DEFAULT 10"},
+			wantErr: false,
+		},
+		{
+			name: "sqlite: changing the type of an existing column",
+			args: args{
+				dbAlias:       "sqlite",
+				tableName:     "table1",
+				project:       "test",
+				parsedSchema:  schemaType{"sqlite": schemaCollection{"table1": SchemaFields{"col1": &SchemaFieldType{FieldName: "col1", Kind: typeInteger}}}},
+				currentSchema: schemaCollection{"table1": SchemaFields{"col1": &SchemaFieldType{FieldName: "col1", Kind: typeString}}},
+			},
+			fields:  fields{crud: crudSQLite, project: "test"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
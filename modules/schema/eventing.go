@@ -1,5 +1,7 @@
 package schema
 
+import "sort"
+
 func (s *Schema) CheckIfEventingIsPossible(dbAlias, col string, obj map[string]interface{}, isFind bool) (findForUpdate map[string]interface{}, present bool) {
 	// Struct to track counts
 	type trackCols struct {
@@ -85,3 +87,22 @@ func isFieldPresentInFindAndIsValidForEventing(fieldName string, obj map[string]
 	}
 	return nil, false
 }
+
+// GetConflictKeys returns the fields of the primary key (or of a unique index) which are completely specified in the
+// find clause. These fields identify the row to be upserted and are used as the conflict target of the upsert query
+func (s *Schema) GetConflictKeys(dbAlias, col string, find map[string]interface{}) ([]string, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	keys, p := s.CheckIfEventingIsPossible(dbAlias, col, find, true)
+	if !p {
+		return nil, false
+	}
+
+	conflictKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		conflictKeys = append(conflictKeys, k)
+	}
+	sort.Strings(conflictKeys)
+	return conflictKeys, true
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -34,18 +35,113 @@ func getSQLType(dbType, typename string) (string, error) {
 		return "float", nil
 	case typeInteger:
 		return "bigint", nil
+	case typeJSON:
+		switch utils.DBType(dbType) {
+		case utils.Postgres:
+			return "jsonb", nil
+		case utils.SqlServer:
+			return "nvarchar(max)", nil
+		}
+		return "json", nil
+	case typeEnum:
+		return getSQLType(dbType, typeString)
 	default:
 		return "", fmt.Errorf("%s type not allowed", typename)
 	}
 }
 
-func checkErrors(realFieldStruct *SchemaFieldType) error {
-	if realFieldStruct.IsList && !realFieldStruct.IsLinked { // array without directive relation not allowed
-		return fmt.Errorf("invalid type for field %s - array type without link directive is not supported in sql creation", realFieldStruct.FieldName)
+// getColumnType returns the sql type of the column of a field. Lists are stored as arrays in postgres and as JSON
+// in the other databases, while enums are native to MySQL alone
+func getColumnType(dbType string, field *SchemaFieldType) (string, error) {
+	switch {
+	case field.IsList && utils.DBType(dbType) == utils.Postgres && field.Kind != typeJSON:
+		sqlType, err := getSQLType(dbType, field.Kind)
+		if err != nil {
+			return "", err
+		}
+		return sqlType + "[]", nil
+
+	case field.IsList:
+		return getSQLType(dbType, typeJSON)
+
+	case field.Kind == typeEnum && utils.DBType(dbType) == utils.MySQL:
+		return "enum(" + quoteEnumValues(field.EnumInfo.Values) + ")", nil
+	}
+	return getSQLType(dbType, field.Kind)
+}
+
+func quoteEnumValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + strings.Replace(value, "'", "''", -1) + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// checkExpression returns the expression of the check constraint of a field. The values of enums (other than in
+// MySQL), JSON in SQL Server and the validators of the field are enforced using check constraints
+func checkExpression(dbType string, field *SchemaFieldType) string {
+	switch {
+	case field.Kind == typeEnum && !field.IsList && utils.DBType(dbType) != utils.MySQL:
+		return field.FieldName + " IN (" + quoteEnumValues(field.EnumInfo.Values) + ")"
+	case (field.Kind == typeJSON || field.IsList) && utils.DBType(dbType) == utils.SqlServer:
+		return "ISJSON(" + field.FieldName + ") = 1"
+	}
+	return strings.Join(validatorConditions(dbType, field), " AND ")
+}
+
+// isEnumModified compares the values of the enums irrespective of their order
+func isEnumModified(realField, currentField *SchemaFieldType) bool {
+	if realField.EnumInfo == nil || currentField.EnumInfo == nil {
+		return realField.EnumInfo != currentField.EnumInfo
+	}
+	return !reflect.DeepEqual(sortedEnumField(realField).EnumInfo.Values, sortedEnumField(currentField).EnumInfo.Values)
+}
+
+// isCheckModified compares the check constraints of the fields irrespective of the order of the enum values
+func isCheckModified(dbType string, realField, currentField *SchemaFieldType) bool {
+	return checkExpression(dbType, sortedEnumField(realField)) != checkExpression(dbType, sortedEnumField(currentField))
+}
+
+func sortedEnumField(field *SchemaFieldType) *SchemaFieldType {
+	if field.EnumInfo == nil {
+		return field
+	}
+	temp := *field
+	temp.EnumInfo = &EnumProperties{Name: field.EnumInfo.Name, Values: append([]string{}, field.EnumInfo.Values...)}
+	sort.Strings(temp.EnumInfo.Values)
+	return &temp
+}
+
+// isColumnTypeModified checks if the type of the column needs to be changed. The values of enums are modified along
+// with their check constraints instead
+func isColumnTypeModified(dbType string, realField, currentField *SchemaFieldType) bool {
+	if realField.Kind == typeEnum && currentField.Kind == typeEnum && realField.IsList == currentField.IsList {
+		return false
+	}
+
+	realType, err := getColumnType(dbType, realField)
+	if err != nil {
+		return realField.Kind != currentField.Kind
+	}
+	currentType, err := getColumnType(dbType, currentField)
+	if err != nil {
+		return realField.Kind != currentField.Kind
 	}
+	return realType != currentType
+}
+
+func checkConstraintName(tableName, columnName string) string {
+	return "check__" + tableName + "__" + columnName
+}
+
+func checkErrors(realFieldStruct *SchemaFieldType) error {
 	if realFieldStruct.Kind == typeObject {
 		return fmt.Errorf("invalid type for field %s - object type not supported in sql creation", realFieldStruct.FieldName)
 	}
+	if realFieldStruct.IsList && realFieldStruct.Kind == typeEnum {
+		return fmt.Errorf("invalid type for field %s - list of enums not supported in sql creation", realFieldStruct.FieldName)
+	}
 
 	if realFieldStruct.IsPrimary && !realFieldStruct.IsFieldTypeRequired {
 		return errors.New("primary key must be required")
@@ -105,7 +201,7 @@ func (c *creationModule) addNewColumn() string {
 	switch utils.DBType(dbType) {
 	case utils.MySQL:
 		return "ALTER TABLE " + getTableName(c.project, c.TableName, c.removeProjectScope) + " ADD " + c.ColumnName + " " + c.columnType
-	case utils.Postgres:
+	case utils.Postgres, utils.SQLite:
 		return "ALTER TABLE " + getTableName(c.project, c.TableName, c.removeProjectScope) + " ADD COLUMN " + c.ColumnName + " " + c.columnType
 	case utils.SqlServer:
 		if c.columnType == "timestamp" && !c.realColumnInfo.IsFieldTypeRequired {
@@ -160,13 +256,34 @@ func (c *creationModule) addForeignKey() string {
 	return "ALTER TABLE " + getTableName(c.project, c.TableName, c.removeProjectScope) + " ADD CONSTRAINT c_" + c.TableName + "_" + c.ColumnName + " FOREIGN KEY (" + c.ColumnName + ") REFERENCES " + getTableName(c.project, c.realColumnInfo.JointTable.Table, c.removeProjectScope) + " (" + c.realColumnInfo.JointTable.To + ")"
 }
 
+func (c *creationModule) addCheckConstraint(dbType string) string {
+	return "ALTER TABLE " + getTableName(c.project, c.TableName, c.removeProjectScope) + " ADD CONSTRAINT " + checkConstraintName(c.TableName, c.ColumnName) + " CHECK (" + checkExpression(dbType, c.realColumnInfo) + ")"
+}
+
+func (c *creationModule) removeCheckConstraint() string {
+	return "ALTER TABLE " + getTableName(c.project, c.TableName, c.removeProjectScope) + " DROP CONSTRAINT " + checkConstraintName(c.TableName, c.ColumnName)
+}
+
+// modifyEnum changes the values of a native enum column of MySQL
+func (c *creationModule) modifyEnum() string {
+	query := "ALTER TABLE " + getTableName(c.project, c.TableName, c.removeProjectScope) + " MODIFY " + c.ColumnName + " " + c.columnType
+	if c.realColumnInfo.IsFieldTypeRequired {
+		query += " NOT NULL"
+	}
+	return query
+}
+
 func (c *creationModule) typeSwitch() string {
 	dbType, err := c.schemaModule.crud.GetDBType(c.dbAlias)
 	if err != nil {
 		return ""
 	}
 
-	switch v := c.realColumnInfo.Default.(type) {
+	return formatDefaultValue(dbType, c.realColumnInfo.Default)
+}
+
+func formatDefaultValue(dbType string, value interface{}) string {
+	switch v := value.(type) {
 	case string:
 		return "'" + fmt.Sprintf("%v", v) + "'"
 	case bool:
@@ -238,18 +355,27 @@ func (c *creationModule) removeForeignKey() []string {
 
 func addNewTable(project, dbType, realColName string, realColValue SchemaFields, removeProjectScope bool) (string, error) {
 
+	// Keys spanning multiple fields are declared as table constraints
+	keys, err := getTableKeys(realColValue)
+	if err != nil {
+		return "", err
+	}
+
+	// The columns are added in order so that the same schema always generates the same query
+	fields := keys.singleKeyFields(realColValue)
 	var query string
-	for realFieldKey, realFieldStruct := range realColValue {
+	for _, realFieldKey := range sortedFieldNames(fields) {
+		realFieldStruct := fields[realFieldKey]
 
 		// Ignore linked fields since these are virtual fields
 		if realFieldStruct.IsLinked {
 			continue
 		}
 
-		if err := checkErrors(realFieldStruct); err != nil {
+		if err := checkErrors(realColValue[realFieldKey]); err != nil {
 			return "", err
 		}
-		sqlType, err := getSQLType(dbType, realFieldStruct.Kind)
+		sqlType, err := getColumnType(dbType, realFieldStruct)
 		if err != nil {
 			return "", nil
 		}
@@ -265,12 +391,47 @@ func addNewTable(project, dbType, realColName string, realColValue SchemaFields,
 			query += " NOT NULL"
 		}
 
+		if expression := checkExpression(dbType, realFieldStruct); expression != "" {
+			query += " CONSTRAINT " + checkConstraintName(realColName, realFieldKey) + " CHECK (" + expression + ")"
+		}
+
+		if utils.DBType(dbType) == utils.SQLite {
+			query += sqliteColumnConstraints(project, dbType, realFieldStruct, removeProjectScope)
+		}
+
 		query += " ,"
 	}
 
+	for _, constraint := range compositeKeyConstraints(project, dbType, realColName, keys, removeProjectScope) {
+		query += constraint + " ,"
+	}
+
 	return `CREATE TABLE ` + getTableName(project, realColName, removeProjectScope) + ` (` + query[0:len(query)-1] + `);`, nil
 }
 
+// sortedFieldNames returns the names of the fields in alphabetical order
+func sortedFieldNames(fields SchemaFields) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sqliteColumnConstraints returns the default and foreign key constraints of a column. SQLite
+// cannot add these to an existing column, so they are declared along with the column itself.
+func sqliteColumnConstraints(project, dbType string, field *SchemaFieldType, removeProjectScope bool) string {
+	var constraints string
+	if field.IsDefault {
+		constraints += " DEFAULT " + formatDefaultValue(dbType, field.Default)
+	}
+	if field.IsForeign {
+		constraints += " REFERENCES " + getTableName(project, field.JointTable.Table, removeProjectScope) + " (" + field.JointTable.To + ")"
+	}
+	return constraints
+}
+
 func getTableName(project, table string, removeProjectScope bool) string {
 	if removeProjectScope {
 		return table
@@ -280,6 +441,17 @@ func getTableName(project, table string, removeProjectScope bool) string {
 }
 
 func (c *creationModule) addColumn(dbType string) []string {
+	if dbType == string(utils.SQLite) {
+		query := c.addNewColumn()
+		if c.realColumnInfo.IsFieldTypeRequired {
+			query += " NOT NULL"
+		}
+		if expression := checkExpression(dbType, c.realColumnInfo); expression != "" {
+			query += " CONSTRAINT " + checkConstraintName(c.TableName, c.ColumnName) + " CHECK (" + expression + ")"
+		}
+		return []string{query + sqliteColumnConstraints(c.project, dbType, c.realColumnInfo, c.removeProjectScope)}
+	}
+
 	var queries []string
 
 	if c.columnType != "" {
@@ -307,6 +479,10 @@ func (c *creationModule) addColumn(dbType string) []string {
 		queries = append(queries, c.addDefaultKey())
 	}
 
+	if checkExpression(dbType, c.realColumnInfo) != "" {
+		queries = append(queries, c.addCheckConstraint(dbType))
+	}
+
 	return queries
 }
 
@@ -314,9 +490,20 @@ func (c *creationModule) removeField() string {
 	return c.removeColumn()
 }
 
-func (c *creationModule) modifyColumn() []string {
+func (c *creationModule) modifyColumn(dbType string) []string {
 	var queries []string
 
+	if c.realColumnInfo.Kind == typeEnum && dbType == string(utils.MySQL) && isEnumModified(c.realColumnInfo, c.currentColumnInfo) {
+		queries = append(queries, c.modifyEnum())
+	} else if isCheckModified(dbType, c.realColumnInfo, c.currentColumnInfo) {
+		if checkExpression(dbType, c.currentColumnInfo) != "" {
+			queries = append(queries, c.removeCheckConstraint())
+		}
+		if checkExpression(dbType, c.realColumnInfo) != "" {
+			queries = append(queries, c.addCheckConstraint(dbType))
+		}
+	}
+
 	if c.realColumnInfo.IsFieldTypeRequired != c.currentColumnInfo.IsFieldTypeRequired {
 		if c.realColumnInfo.IsFieldTypeRequired {
 			queries = append(queries, c.addNotNull())
@@ -359,6 +546,9 @@ func (c *creationModule) modifyColumnType(dbType string) []string {
 	if c.currentColumnInfo.IsForeign {
 		queries = append(queries, c.removeForeignKey()...)
 	}
+	if checkExpression(dbType, c.currentColumnInfo) != "" {
+		queries = append(queries, c.removeCheckConstraint())
+	}
 	queries = append(queries, c.removeColumn())
 
 	q := c.addColumn(dbType)
@@ -392,6 +582,8 @@ func removeIndex(dbType, project, tableName, indexName string, removeProjectScop
 	case utils.Postgres:
 		indexname := "index__" + tableName + "__" + indexName
 		return "DROP INDEX " + getTableName(project, indexname, removeProjectScope)
+	case utils.SQLite:
+		return "DROP INDEX " + "index__" + tableName + "__" + indexName
 	}
 	return ""
 }
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGetColumnType(t *testing.T) {
+	enum := &EnumProperties{Name: "Status", Values: []string{"ACTIVE", "INACTIVE"}}
+	tests := []struct {
+		name, dbType string
+		field        *SchemaFieldType
+		want, check  string
+	}{
+		{name: "postgres json", dbType: "postgres", field: &SchemaFieldType{FieldName: "col", Kind: typeJSON}, want: "jsonb"},
+		{name: "mysql json", dbType: "mysql", field: &SchemaFieldType{FieldName: "col", Kind: typeJSON}, want: "json"},
+		{name: "sqlserver json", dbType: "sqlserver", field: &SchemaFieldType{FieldName: "col", Kind: typeJSON}, want: "nvarchar(max)", check: "ISJSON(col) = 1"},
+		{name: "postgres list", dbType: "postgres", field: &SchemaFieldType{FieldName: "col", Kind: typeString, IsList: true}, want: "text[]"},
+		{name: "mysql list", dbType: "mysql", field: &SchemaFieldType{FieldName: "col", Kind: typeInteger, IsList: true}, want: "json"},
+		{name: "sqlserver list", dbType: "sqlserver", field: &SchemaFieldType{FieldName: "col", Kind: typeString, IsList: true}, want: "nvarchar(max)", check: "ISJSON(col) = 1"},
+		{name: "mysql enum", dbType: "mysql", field: &SchemaFieldType{FieldName: "col", Kind: typeEnum, EnumInfo: enum}, want: "enum('ACTIVE', 'INACTIVE')"},
+		{name: "postgres enum", dbType: "postgres", field: &SchemaFieldType{FieldName: "col", Kind: typeEnum, EnumInfo: enum}, want: "text", check: "col IN ('ACTIVE', 'INACTIVE')"},
+		{name: "sqlite enum", dbType: "sqlite", field: &SchemaFieldType{FieldName: "col", Kind: typeEnum, EnumInfo: enum}, want: "text", check: "col IN ('ACTIVE', 'INACTIVE')"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getColumnType(tt.dbType, tt.field)
+			if err != nil {
+				t.Fatalf("getColumnType() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("getColumnType() = %s; want %s", got, tt.want)
+			}
+			if check := checkExpression(tt.dbType, tt.field); check != tt.check {
+				t.Errorf("checkExpression() = %s; want %s", check, tt.check)
+			}
+		})
+	}
+}
+
+func TestSchema_ColumnTypes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-types")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tables := map[string]*config.TableRule{"todos": {Schema: `
+		type todos {
+			id: ID! @primary
+			meta: JSON
+			tags: [String]
+			status: Status!
+		}
+		enum Status { ACTIVE INACTIVE }`}}
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: tables}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not create the table:", err)
+	}
+
+	// The inspected schema must match the one used to create the table
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the table:", err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("PlanSchemaModifyAll() = %v; want no queries for the created table", plan)
+	}
+	sdl, err := s.SchemaInspection(ctx, "sqlite", "project", "todos")
+	if err != nil {
+		t.Fatal("could not inspect the table:", err)
+	}
+	for _, want := range []string{"meta: JSON", "status: todos_status!", "enum todos_status { ACTIVE INACTIVE }"} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SchemaInspection() = %s; want it to contain %s", sdl, want)
+		}
+	}
+
+	// Values of enums are validated
+	req := &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"id": "1", "status": "DONE"}}
+	if err := s.ValidateCreateOperation("sqlite", "todos", req); err == nil {
+		t.Error("ValidateCreateOperation() succeeded for an invalid enum value")
+	}
+
+	doc := map[string]interface{}{
+		"id":     "1",
+		"meta":   map[string]interface{}{"priority": float64(1), "labels": []interface{}{"a"}},
+		"tags":   []interface{}{"home", "work"},
+		"status": "ACTIVE",
+	}
+	req = &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"id": doc["id"], "meta": doc["meta"], "tags": doc["tags"], "status": doc["status"]}}
+	if err := s.ValidateCreateOperation("sqlite", "todos", req); err != nil {
+		t.Fatal("could not validate the document:", err)
+	}
+	if err := c.InternalCreate(ctx, "sqlite", "project", "todos", req); err != nil {
+		t.Fatal("could not create the document:", err)
+	}
+
+	result, err := c.InternalRead(ctx, "sqlite", "project", "todos", &model.ReadRequest{Find: map[string]interface{}{"id": "1"}, Operation: utils.One})
+	if err != nil {
+		t.Fatal("could not read the document:", err)
+	}
+	if !reflect.DeepEqual(result, doc) {
+		t.Errorf("Read() = %v; want %v", result, doc)
+	}
+}
@@ -5,24 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/spaceuptech/space-cloud/config"
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
-// SchemaInspection returns the schema in schema definition language (SDL)
+// SchemaInspection returns the schema in schema definition language (SDL). The schema of mongo collections is
+// inferred from a sample of their documents
 func (s *Schema) SchemaInspection(ctx context.Context, dbAlias, project, col string) (string, error) {
-	dbType, err := s.crud.GetDBType(dbAlias)
-	if err != nil {
-		return "", err
-	}
-
-	if dbType == "mongo" {
-		return "", nil
-	}
-
-	inspectionCollection, err := s.Inspector(ctx, dbType, project, col)
+	inspectionCollection, err := s.Inspector(ctx, dbAlias, project, col)
 	if err != nil {
 		return "", err
 	}
@@ -32,19 +26,136 @@ func (s *Schema) SchemaInspection(ctx context.Context, dbAlias, project, col str
 }
 
 // Inspector generates schema
-func (s *Schema) Inspector(ctx context.Context, dbType, project, col string) (schemaCollection, error) {
-	fields, foreignkeys, indexes, err := s.crud.DescribeTable(ctx, dbType, project, col)
+func (s *Schema) Inspector(ctx context.Context, dbAlias, project, col string) (schemaCollection, error) {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return nil, err
+	}
 
+	fields, foreignkeys, indexes, err := s.crud.DescribeTable(ctx, dbAlias, project, col)
 	if err != nil {
 		return nil, err
 	}
+	if dbType == string(utils.Mongo) {
+		return generateMongoInspection(col, fields, indexes)
+	}
 	return generateInspection(dbType, col, fields, foreignkeys, indexes)
 }
 
+// generateMongoInspection generates the schema of a mongo collection from the fields inferred from its documents.
+// The fields of nested objects are described by their path and get a type of their own in the schema
+func generateMongoInspection(col string, fields []utils.FieldType, indexes []utils.IndexType) (schemaCollection, error) {
+	inspectionCollection := schemaCollection{}
+	inspectionFields := SchemaFields{}
+
+	// The fields are sorted by their path, so the parent of a field is always inspected before it
+	sort.Slice(fields, func(i, j int) bool { return fields[i].FieldName < fields[j].FieldName })
+	jsonFields := map[string]bool{}
+	for _, field := range fields {
+		path := strings.Split(field.FieldName, ".")
+
+		// The fields of an object holding values of other types in some documents are part of its JSON value
+		if isJSONDescendant(path, jsonFields) {
+			continue
+		}
+
+		fieldDetails := SchemaFieldType{FieldName: path[len(path)-1], IsFieldTypeRequired: field.FieldNull == "NO", IsPrimary: field.FieldKey == "PRI"}
+		inspectionMongoCheckFieldType(field.FieldType, &fieldDetails)
+		if fieldDetails.Kind == typeObject {
+			fieldDetails.nestedObject = SchemaFields{}
+			fieldDetails.JointTable = &TableProperties{Table: col + "_" + strings.Join(path, "_")}
+		}
+
+		for _, indexValue := range indexes {
+			if indexValue.ColumnName == field.FieldName {
+				fieldDetails.IsIndex = true
+				fieldDetails.IsUnique = indexValue.IsUnique == "yes"
+				fieldDetails.IndexInfo = &TableProperties{Group: indexValue.IndexName, Order: indexValue.Order, Sort: indexValue.Sort}
+			}
+		}
+
+		// Find the object the field belongs to
+		parent := inspectionFields
+		for _, name := range path[:len(path)-1] {
+			parentField, ok := parent[name]
+			if !ok || parentField.nestedObject == nil {
+				return nil, fmt.Errorf("invalid field (%s) inferred for collection %s - %s is not an object", field.FieldName, col, name)
+			}
+			parent = parentField.nestedObject
+		}
+		parent[fieldDetails.FieldName] = &fieldDetails
+		if fieldDetails.Kind == typeJSON {
+			jsonFields[field.FieldName] = true
+		}
+	}
+
+	if len(inspectionFields) != 0 {
+		inspectionCollection[col] = inspectionFields
+	}
+	return inspectionCollection, nil
+}
+
+// isJSONDescendant checks if any of the ancestors of the field at the path was inspected as JSON
+func isJSONDescendant(path []string, jsonFields map[string]bool) bool {
+	for i := 1; i < len(path); i++ {
+		if jsonFields[strings.Join(path[:i], ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+// inspectionMongoCheckFieldType maps the bson type inferred for a field to its type in the schema. Fields holding
+// values of different types are inspected as JSON
+func inspectionMongoCheckFieldType(typeName string, fieldDetails *SchemaFieldType) {
+	if strings.HasPrefix(typeName, "array<") {
+		fieldDetails.IsList = true
+		typeName = strings.TrimSuffix(strings.TrimPrefix(typeName, "array<"), ">")
+	}
+
+	switch typeName {
+	case "objectId":
+		fieldDetails.Kind = TypeID
+	case "string":
+		fieldDetails.Kind = typeString
+	case "int", "long":
+		fieldDetails.Kind = typeInteger
+	case "double", "decimal":
+		fieldDetails.Kind = typeFloat
+	case "bool":
+		fieldDetails.Kind = typeBoolean
+	case "date":
+		fieldDetails.Kind = typeDateTime
+	case "object":
+		fieldDetails.Kind = typeObject
+	default:
+		// A list of mixed values is a JSON array
+		fieldDetails.Kind = typeJSON
+		fieldDetails.IsList = false
+	}
+
+	// The _id of documents is an ID even if it's a string
+	if fieldDetails.IsPrimary && fieldDetails.Kind == typeString {
+		fieldDetails.Kind = TypeID
+	}
+}
+
 func generateInspection(dbType, col string, fields []utils.FieldType, foreignkeys []utils.ForeignKeysType, indexes []utils.IndexType) (schemaCollection, error) {
 	inspectionCollection := schemaCollection{}
 	inspectionFields := SchemaFields{}
 
+	// Count the columns of the primary and foreign keys to identify the keys spanning multiple columns
+	primaryKeyCount, primaryKeyOrder := 0, 0
+	for _, field := range fields {
+		if field.FieldKey == "PRI" {
+			primaryKeyCount++
+		}
+	}
+	foreignKeyCount := map[string]int{}
+	for _, foreignValue := range foreignkeys {
+		foreignKeyCount[foreignValue.ConstraintName]++
+	}
+
 	for _, field := range fields {
 		fieldDetails := SchemaFieldType{FieldName: field.FieldName}
 
@@ -65,6 +176,12 @@ func generateInspection(dbType, col string, fields []utils.FieldType, foreignkey
 			}
 		}
 
+		// Enums, the JSON fields of SQL Server and the validators are identified by their check constraints
+		inspectionCheckConstraint(col, field.FieldCheck, &fieldDetails)
+		if fieldDetails.Kind == typeEnum && fieldDetails.EnumInfo.Name == "" {
+			fieldDetails.EnumInfo.Name = col + "_" + field.FieldName
+		}
+
 		// default key
 		if field.FieldDefault != "" {
 			fieldDetails.IsDefault = true
@@ -80,17 +197,22 @@ func generateInspection(dbType, col string, fields []utils.FieldType, foreignkey
 				}
 			}
 
+			if utils.DBType(dbType) == utils.SQLite {
+				// remove the quotes around string defaults e.g -> 'default-value' -> default-value
+				field.FieldDefault = strings.Trim(field.FieldDefault, "'")
+			}
+
 			if utils.DBType(dbType) == utils.Postgres {
 				// split "'default-value'::text" to "default-value"
 				s := strings.Split(field.FieldDefault, "::")
 				field.FieldDefault = s[0]
-				if fieldDetails.Kind == typeString || fieldDetails.Kind == typeDateTime || fieldDetails.Kind == TypeID {
+				if fieldDetails.Kind == typeString || fieldDetails.Kind == typeDateTime || fieldDetails.Kind == TypeID || fieldDetails.Kind == typeEnum {
 					field.FieldDefault = strings.Split(field.FieldDefault, "'")[1]
 				}
 			}
 
 			// add string between quotes
-			if fieldDetails.Kind == typeString || fieldDetails.Kind == TypeID || fieldDetails.Kind == typeDateTime {
+			if fieldDetails.Kind == typeString || fieldDetails.Kind == TypeID || fieldDetails.Kind == typeDateTime || fieldDetails.Kind == typeEnum {
 				field.FieldDefault = fmt.Sprintf("\"%s\"", field.FieldDefault)
 			}
 			fieldDetails.Default = field.FieldDefault
@@ -99,6 +221,16 @@ func generateInspection(dbType, col string, fields []utils.FieldType, foreignkey
 		// check if list
 		if field.FieldKey == "PRI" {
 			fieldDetails.IsPrimary = true
+
+			// The fields of a primary key spanning multiple fields are ordered as they appear in the table if
+			// their position in the key isn't known
+			if primaryKeyCount > 1 {
+				primaryKeyOrder++
+				fieldDetails.PrimaryKeyInfo = &TableProperties{Order: primaryKeyOrder, ConstraintName: field.FieldKeyConstraint}
+				if field.FieldKeyOrder > 0 {
+					fieldDetails.PrimaryKeyInfo.Order = field.FieldKeyOrder
+				}
+			}
 		}
 
 		// check foreignKey & identify if relation exists
@@ -106,6 +238,13 @@ func generateInspection(dbType, col string, fields []utils.FieldType, foreignkey
 			if foreignValue.ColumnName == field.FieldName && foreignValue.RefTableName != "" && foreignValue.RefColumnName != "" {
 				fieldDetails.IsForeign = true
 				fieldDetails.JointTable = &TableProperties{Table: foreignValue.RefTableName, To: foreignValue.RefColumnName}
+
+				// The group of a foreign key spanning multiple fields is derived from the name of its constraint
+				if foreignKeyCount[foreignValue.ConstraintName] > 1 {
+					fieldDetails.JointTable.Group = strings.TrimPrefix(foreignValue.ConstraintName, "c_"+col+"_")
+					fieldDetails.JointTable.Order = foreignValue.OrdinalPosition
+					fieldDetails.JointTable.ConstraintName = foreignValue.ConstraintName
+				}
 			}
 		}
 		for _, indexValue := range indexes {
@@ -126,6 +265,30 @@ func generateInspection(dbType, col string, fields []utils.FieldType, foreignkey
 	return inspectionCollection, nil
 }
 
+var enumValueRegex = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// inspectionEnumValues returns the quoted values in the definition of an enum or a check constraint
+func inspectionEnumValues(definition string) []string {
+	values := []string{}
+	for _, match := range enumValueRegex.FindAllStringSubmatch(definition, -1) {
+		values = append(values, strings.Replace(match[1], "''", "'", -1))
+	}
+	return values
+}
+
+func inspectionCheckConstraint(col, check string, fieldDetails *SchemaFieldType) {
+	switch {
+	case check == "":
+	case strings.Contains(strings.ToUpper(check), "ISJSON"):
+		fieldDetails.Kind = typeJSON
+	case fieldDetails.Kind == typeInteger || fieldDetails.Kind == typeFloat || isValidatorCheck(check):
+		inspectionValidators(check, fieldDetails)
+	default:
+		fieldDetails.Kind = typeEnum
+		fieldDetails.EnumInfo = &EnumProperties{Values: inspectionEnumValues(check)}
+	}
+}
+
 func inspectionMySQLCheckFieldType(typeName string, fieldDetails *SchemaFieldType) error {
 	if typeName == "varchar("+sqlTypeIDSize+")" {
 		fieldDetails.Kind = TypeID
@@ -135,6 +298,13 @@ func inspectionMySQLCheckFieldType(typeName string, fieldDetails *SchemaFieldTyp
 	result := strings.Split(typeName, "(")
 
 	switch result[0] {
+	case "enum":
+		fieldDetails.Kind = typeEnum
+		fieldDetails.EnumInfo = &EnumProperties{Values: inspectionEnumValues(typeName)}
+	case "json":
+		fieldDetails.Kind = typeJSON
+	case "nvarchar":
+		fieldDetails.Kind = typeString
 	case "varchar":
 		fieldDetails.Kind = typeString // for sql server
 	case "char", "tinytext", "text", "blob", "mediumtext", "mediumblob", "longtext", "longblob", "decimal":
@@ -159,10 +329,36 @@ func inspectionPostgresCheckFieldType(typeName string, fieldDetails *SchemaField
 		return nil
 	}
 
+	// The type of the elements of arrays is their udt name prefixed with an underscore
+	if strings.HasPrefix(typeName, "_") {
+		fieldDetails.IsList = true
+		switch typeName {
+		case "_varchar":
+			fieldDetails.Kind = TypeID
+		case "_text", "_bpchar":
+			fieldDetails.Kind = typeString
+		case "_int2", "_int4", "_int8", "_numeric":
+			fieldDetails.Kind = typeInteger
+		case "_float4", "_float8":
+			fieldDetails.Kind = typeFloat
+		case "_bool":
+			fieldDetails.Kind = typeBoolean
+		case "_date", "_time", "_timestamp", "_timestamptz":
+			fieldDetails.Kind = typeDateTime
+		case "_json", "_jsonb":
+			fieldDetails.Kind = typeJSON
+		default:
+			return errors.New("Inspection type check : no match found got " + typeName)
+		}
+		return nil
+	}
+
 	result := strings.Split(typeName, " ")
 	result = strings.Split(result[0], "(")
 
 	switch result[0] {
+	case "json", "jsonb":
+		fieldDetails.Kind = typeJSON
 	case "character", "bit", "text":
 		fieldDetails.Kind = typeString
 	case "bigint", "bigserial", "integer", "numeric", "smallint", "smallserial", "serial":
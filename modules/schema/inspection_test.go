@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/modules/crud"
 	"github.com/spaceuptech/space-cloud/utils"
 )
 
@@ -250,3 +252,108 @@ func print(val interface{}) string {
 	b, _ := json.MarshalIndent(val, "", "  ")
 	return string(b)
 }
+
+func Test_generateInspectionTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		field  utils.FieldType
+		want   *SchemaFieldType
+	}{
+		{
+			name:   "postgres array",
+			dbType: "postgres",
+			field:  utils.FieldType{FieldName: "col1", FieldType: "_text", FieldNull: "YES"},
+			want:   &SchemaFieldType{FieldName: "col1", IsList: true, Kind: typeString},
+		},
+		{
+			name:   "postgres json",
+			dbType: "postgres",
+			field:  utils.FieldType{FieldName: "col1", FieldType: "jsonb", FieldNull: "YES"},
+			want:   &SchemaFieldType{FieldName: "col1", Kind: typeJSON},
+		},
+		{
+			name:   "postgres enum",
+			dbType: "postgres",
+			field:  utils.FieldType{FieldName: "col1", FieldType: "text", FieldNull: "NO", FieldCheck: "CHECK ((col1 = ANY (ARRAY['A'::text, 'B'::text])))"},
+			want:   &SchemaFieldType{FieldName: "col1", IsFieldTypeRequired: true, Kind: typeEnum, EnumInfo: &EnumProperties{Name: "table1_col1", Values: []string{"A", "B"}}},
+		},
+		{
+			name:   "mysql enum",
+			dbType: "mysql",
+			field:  utils.FieldType{FieldName: "col1", FieldType: "enum('A','B')", FieldNull: "YES"},
+			want:   &SchemaFieldType{FieldName: "col1", Kind: typeEnum, EnumInfo: &EnumProperties{Name: "table1_col1", Values: []string{"A", "B"}}},
+		},
+		{
+			name:   "mysql json",
+			dbType: "mysql",
+			field:  utils.FieldType{FieldName: "col1", FieldType: "json", FieldNull: "YES"},
+			want:   &SchemaFieldType{FieldName: "col1", Kind: typeJSON},
+		},
+		{
+			name:   "sqlserver json",
+			dbType: "sqlserver",
+			field:  utils.FieldType{FieldName: "col1", FieldType: "nvarchar", FieldNull: "YES", FieldCheck: "(isjson([col1])=(1))"},
+			want:   &SchemaFieldType{FieldName: "col1", Kind: typeJSON},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generateInspection(tt.dbType, "table1", []utils.FieldType{tt.field}, nil, nil)
+			if err != nil {
+				t.Fatalf("generateInspection() error = %v", err)
+			}
+			if !reflect.DeepEqual(got["table1"]["col1"], tt.want) {
+				t.Errorf("generateInspection() = %s, want %s", print(got["table1"]["col1"]), print(tt.want))
+			}
+		})
+	}
+}
+
+func Test_generateMongoInspection(t *testing.T) {
+	fields := []utils.FieldType{
+		{FieldName: "_id", FieldType: "objectId", FieldNull: "NO", FieldKey: "PRI"},
+		{FieldName: "address.city", FieldType: "string", FieldNull: "NO"},
+		{FieldName: "address", FieldType: "object", FieldNull: "NO"},
+		{FieldName: "address.geo", FieldType: "object", FieldNull: "YES"},
+		{FieldName: "address.geo.lat", FieldType: "double", FieldNull: "NO"},
+		{FieldName: "age", FieldType: "long", FieldNull: "YES"},
+		{FieldName: "meta", FieldType: "mixed", FieldNull: "YES"},
+		{FieldName: "meta.y", FieldType: "object", FieldNull: "YES"},
+		{FieldName: "meta.y.z", FieldType: "string", FieldNull: "YES"},
+		{FieldName: "tags", FieldType: "array<string>", FieldNull: "NO"},
+	}
+	indexes := []utils.IndexType{{TableName: "users", ColumnName: "age", IndexName: "age_1", Order: 1, Sort: "asc", IsUnique: "no"}}
+
+	inspected, err := generateMongoInspection("users", fields, indexes)
+	if err != nil {
+		t.Fatal("generateMongoInspection() error:", err)
+	}
+	sdl, err := generateSDL(inspected)
+	if err != nil {
+		t.Fatal("generateSDL() error:", err)
+	}
+
+	// The generated schema must describe the collection once parsed
+	s := Init(crud.Init(false), false)
+	parsed, err := s.parser(config.Crud{"mongo": &config.CrudStub{Collections: map[string]*config.TableRule{"users": {Schema: sdl}}}})
+	if err != nil {
+		t.Fatalf("could not parse the generated schema %s: %v", sdl, err)
+	}
+	users := parsed["mongo"]["users"]
+	for name, want := range map[string]string{"_id": TypeID, "address": typeObject, "age": typeInteger, "meta": typeJSON, "tags": typeString} {
+		if field, ok := users[name]; !ok || field.Kind != want {
+			t.Errorf("generateSDL() = %s; want field %s of type %s", sdl, name, want)
+		}
+	}
+	if !users["_id"].IsPrimary || !users["tags"].IsList || !users["age"].IsIndex || users["age"].IsFieldTypeRequired {
+		t.Errorf("generateSDL() = %s; want the directives of the fields to be retained", sdl)
+	}
+	if users["meta"].nestedObject != nil {
+		t.Errorf("generateSDL() = %s; want the fields of the mixed field to be part of its JSON value", sdl)
+	}
+	geo, ok := users["address"].nestedObject["geo"]
+	if !ok || geo.Kind != typeObject || geo.nestedObject["lat"] == nil || geo.nestedObject["lat"].Kind != typeFloat {
+		t.Errorf("generateSDL() = %s; want the nested objects to be retained", sdl)
+	}
+}
@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// SetMongoValidator sets the schema (in SDL) of a mongo collection as the $jsonSchema validator of the collection, so
+// that the documents written to it directly are validated by mongo as well
+func (s *Schema) SetMongoValidator(ctx context.Context, dbAlias, project, col, schema string) error {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return err
+	}
+	if dbType != string(utils.Mongo) {
+		return fmt.Errorf("json schema validator can only be set on mongo - got %s", dbType)
+	}
+
+	parsedSchema, err := s.parser(config.Crud{dbAlias: &config.CrudStub{Collections: map[string]*config.TableRule{col: {Schema: schema}}}})
+	if err != nil {
+		return err
+	}
+	fields, ok := parsedSchema[dbAlias][col]
+	if !ok {
+		return fmt.Errorf("no fields found in the schema of collection %s", col)
+	}
+
+	return s.crud.SetValidator(ctx, dbAlias, project, col, generateJSONSchema(fields))
+}
+
+// generateJSONSchema converts the fields of a collection to the $jsonSchema of mongo
+func generateJSONSchema(fields SchemaFields) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+	for name, field := range fields {
+		// Linked fields aren't stored in the collection
+		if field.IsLinked {
+			continue
+		}
+
+		property := jsonSchemaValue(field)
+		if field.IsList {
+			property = map[string]interface{}{"bsonType": "array", "items": property}
+		}
+		if field.IsFieldTypeRequired {
+			required = append(required, name)
+		} else if bsonType, ok := property["bsonType"]; ok {
+			property["bsonType"] = appendBSONType(bsonType, "null")
+		} else if values, ok := property["enum"].([]interface{}); ok {
+			property["enum"] = append(values, nil)
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]interface{}{"bsonType": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// jsonSchemaValue returns the json schema of a single value of a field
+func jsonSchemaValue(field *SchemaFieldType) map[string]interface{} {
+	property := map[string]interface{}{}
+	switch field.Kind {
+	case TypeID:
+		property["bsonType"] = []interface{}{"string", "objectId"}
+	case typeString:
+		property["bsonType"] = "string"
+	case typeEnum:
+		values := make([]interface{}, len(field.EnumInfo.Values))
+		for i, value := range field.EnumInfo.Values {
+			values[i] = value
+		}
+		property["enum"] = values
+	case typeInteger:
+		property["bsonType"] = []interface{}{"int", "long"}
+	case typeFloat:
+		property["bsonType"] = []interface{}{"double", "decimal", "int", "long"}
+	case typeBoolean:
+		property["bsonType"] = "bool"
+	case typeDateTime:
+		property["bsonType"] = "date"
+	case typeObject:
+		property = generateJSONSchema(field.nestedObject)
+	}
+
+	if v := field.Validators; v != nil {
+		if v.MinLength != nil {
+			property["minLength"] = *v.MinLength
+		}
+		if v.MaxLength != nil {
+			property["maxLength"] = *v.MaxLength
+		}
+		if v.Min != nil {
+			property["minimum"] = *v.Min
+		}
+		if v.Max != nil {
+			property["maximum"] = *v.Max
+		}
+
+		var patterns []interface{}
+		if v.Pattern != "" {
+			patterns = append(patterns, map[string]interface{}{"pattern": v.Pattern})
+		}
+		if v.IsEmail {
+			patterns = append(patterns, map[string]interface{}{"pattern": emailPattern})
+		}
+		if len(patterns) > 0 {
+			property["allOf"] = patterns
+		}
+	}
+	return property
+}
+
+func appendBSONType(bsonType interface{}, t string) interface{} {
+	switch v := bsonType.(type) {
+	case string:
+		return []interface{}{v, t}
+	case []interface{}:
+		return append(v, t)
+	}
+	return bsonType
+}
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	schema := `type users {
+		_id: ID! @primary
+		name: String! @length(max: 10)
+		age: Integer @range(min: 0)
+		tags: [String]
+		role: Role
+		address: address
+		posts: [posts] @link(table: "posts", from: "_id", to: "author")
+	}
+	type address { city: String! zip: Integer }
+	enum Role { ADMIN USER }`
+
+	s := Init(crud.Init(false), false)
+	parsed, err := s.parser(config.Crud{"mongo": &config.CrudStub{Collections: map[string]*config.TableRule{"users": {Schema: schema}}}})
+	if err != nil {
+		t.Fatal("could not parse schema:", err)
+	}
+
+	got := generateJSONSchema(parsed["mongo"]["users"])
+	want := map[string]interface{}{
+		"bsonType": "object",
+		"properties": map[string]interface{}{
+			"_id":  map[string]interface{}{"bsonType": []interface{}{"string", "objectId"}},
+			"name": map[string]interface{}{"bsonType": "string", "maxLength": 10},
+			"age":  map[string]interface{}{"bsonType": []interface{}{"int", "long", "null"}, "minimum": float64(0)},
+			"tags": map[string]interface{}{"bsonType": []interface{}{"array", "null"}, "items": map[string]interface{}{"bsonType": "string"}},
+			"role": map[string]interface{}{"enum": []interface{}{"ADMIN", "USER", nil}},
+			"address": map[string]interface{}{
+				"bsonType": []interface{}{"object", "null"},
+				"properties": map[string]interface{}{
+					"city": map[string]interface{}{"bsonType": "string"},
+					"zip":  map[string]interface{}{"bsonType": []interface{}{"int", "long", "null"}},
+				},
+				"required": []interface{}{"city"},
+			},
+		},
+	}
+	required, _ := got["required"].([]interface{})
+	delete(got, "required")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateJSONSchema() = %v; want %v", got, want)
+	}
+	if len(required) != 2 {
+		t.Errorf("generateJSONSchema() required = %v; want _id and name", required)
+	}
+}
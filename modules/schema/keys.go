@@ -0,0 +1,256 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// foreignKeyStruct is a foreign key which may span multiple fields of a table
+type foreignKeyStruct struct {
+	Group          string
+	Table          string
+	Fields         []*SchemaFieldType
+	ConstraintName string
+}
+
+// tableKeys holds the keys of a table which span multiple fields. Keys made on a single field are handled
+// along with the field itself
+type tableKeys struct {
+	primary []*SchemaFieldType
+	foreign map[string]*foreignKeyStruct
+}
+
+// getTableKeys returns the primary and foreign keys of a table which span multiple fields
+func getTableKeys(tableInfo SchemaFields) (*tableKeys, error) {
+	keys := &tableKeys{foreign: map[string]*foreignKeyStruct{}}
+
+	for _, field := range tableInfo {
+		if field.IsLinked {
+			continue
+		}
+		if field.IsPrimary {
+			keys.primary = append(keys.primary, field)
+		}
+		if field.IsForeign {
+			group := foreignKeyGroup(field)
+			fk, ok := keys.foreign[group]
+			if !ok {
+				fk = &foreignKeyStruct{Group: group, Table: field.JointTable.Table, ConstraintName: field.JointTable.ConstraintName}
+				keys.foreign[group] = fk
+			}
+			if fk.Table != field.JointTable.Table {
+				return nil, fmt.Errorf("invalid foreign key (%s) - all fields must reference the same table", group)
+			}
+			fk.Fields = append(fk.Fields, field)
+		}
+	}
+
+	if len(keys.primary) > 1 {
+		sort.Slice(keys.primary, func(i, j int) bool {
+			return primaryKeyOrder(keys.primary[i]) < primaryKeyOrder(keys.primary[j]) ||
+				(primaryKeyOrder(keys.primary[i]) == primaryKeyOrder(keys.primary[j]) && keys.primary[i].FieldName < keys.primary[j].FieldName)
+		})
+		for i, field := range keys.primary {
+			if i+1 != primaryKeyOrder(field) {
+				return nil, fmt.Errorf("invalid order (%d) of primary key field %s", primaryKeyOrder(field), field.FieldName)
+			}
+		}
+	} else {
+		keys.primary = nil
+	}
+
+	for group, fk := range keys.foreign {
+		if len(fk.Fields) == 1 {
+			delete(keys.foreign, group)
+			continue
+		}
+		sort.Slice(fk.Fields, func(i, j int) bool {
+			return fk.Fields[i].JointTable.Order < fk.Fields[j].JointTable.Order ||
+				(fk.Fields[i].JointTable.Order == fk.Fields[j].JointTable.Order && fk.Fields[i].FieldName < fk.Fields[j].FieldName)
+		})
+		for i, field := range fk.Fields {
+			if i+1 != field.JointTable.Order {
+				return nil, fmt.Errorf("invalid order (%d) of field %s in foreign key (%s)", field.JointTable.Order, field.FieldName, group)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+func primaryKeyOrder(field *SchemaFieldType) int {
+	if field.PrimaryKeyInfo == nil {
+		return 0
+	}
+	return field.PrimaryKeyInfo.Order
+}
+
+// foreignKeyGroup returns the group of a foreign key field. A field without a group forms a foreign key on its own
+func foreignKeyGroup(field *SchemaFieldType) string {
+	if field.JointTable.Group != "" {
+		return field.JointTable.Group
+	}
+	return field.FieldName
+}
+
+// singleKeyFields returns the fields of the table with the fields of keys spanning multiple fields marked as regular
+// fields. The fields themselves are never modified
+func (k *tableKeys) singleKeyFields(tableInfo SchemaFields) SchemaFields {
+	fields := make(SchemaFields, len(tableInfo))
+	for name, field := range tableInfo {
+		fields[name] = field
+	}
+
+	for _, field := range k.primary {
+		temp := *fields[field.FieldName]
+		temp.IsPrimary = false
+		fields[field.FieldName] = &temp
+	}
+	for _, fk := range k.foreign {
+		for _, field := range fk.Fields {
+			temp := *fields[field.FieldName]
+			temp.IsForeign = false
+			fields[field.FieldName] = &temp
+		}
+	}
+	return fields
+}
+
+// primaryKeySignature identifies the primary key irrespective of the order of its fields. The order isn't
+// compared since it cannot be inspected reliably across databases
+func (k *tableKeys) primaryKeySignature() string {
+	names := make([]string, len(k.primary))
+	for i, field := range k.primary {
+		names[i] = field.FieldName
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func (fk *foreignKeyStruct) signature() string {
+	s := fk.Table
+	for _, field := range fk.Fields {
+		s += "," + field.FieldName + ":" + field.JointTable.To
+	}
+	return s
+}
+
+// foreignKeySignatures indexes the foreign keys by their signature
+func (k *tableKeys) foreignKeySignatures() map[string]*foreignKeyStruct {
+	fks := make(map[string]*foreignKeyStruct, len(k.foreign))
+	for _, fk := range k.foreign {
+		fks[fk.signature()] = fk
+	}
+	return fks
+}
+
+func (fk *foreignKeyStruct) columns() (string, string) {
+	from, to := make([]string, len(fk.Fields)), make([]string, len(fk.Fields))
+	for i, field := range fk.Fields {
+		from[i], to[i] = field.FieldName, field.JointTable.To
+	}
+	return strings.Join(from, ", "), strings.Join(to, ", ")
+}
+
+// primaryKeyConstraintName returns the name of the primary key constraint. The name found by inspection is used for
+// existing keys since tables which weren't created by us name their keys differently. The fields are sorted by their
+// names since the order of the fields isn't available while dropping an inspected primary key
+func primaryKeyConstraintName(tableName string, fields []*SchemaFieldType) string {
+	for _, field := range fields {
+		if field.PrimaryKeyInfo != nil && field.PrimaryKeyInfo.ConstraintName != "" {
+			return field.PrimaryKeyInfo.ConstraintName
+		}
+	}
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.FieldName
+	}
+	sort.Strings(names)
+	return "c_" + tableName + "_" + strings.Join(names, "_")
+}
+
+func foreignKeyConstraintName(tableName string, fk *foreignKeyStruct) string {
+	if fk.ConstraintName != "" {
+		return fk.ConstraintName
+	}
+	return "c_" + tableName + "_" + fk.Group
+}
+
+// compositeKeyConstraints returns the constraints to be declared while creating a table for the keys spanning
+// multiple fields. Only SQLite needs the foreign keys to be declared along with the table
+func compositeKeyConstraints(project, dbType, tableName string, keys *tableKeys, removeProjectScope bool) []string {
+	var constraints []string
+	if len(keys.primary) > 0 {
+		names := make([]string, len(keys.primary))
+		for i, field := range keys.primary {
+			names[i] = field.FieldName
+		}
+		constraints = append(constraints, "CONSTRAINT "+primaryKeyConstraintName(tableName, keys.primary)+" PRIMARY KEY ("+strings.Join(names, ", ")+")")
+	}
+
+	if utils.DBType(dbType) == utils.SQLite {
+		for _, group := range sortedKeys(keys.foreign) {
+			fk := keys.foreign[group]
+			from, to := fk.columns()
+			constraints = append(constraints, "CONSTRAINT "+foreignKeyConstraintName(tableName, fk)+" FOREIGN KEY ("+from+") REFERENCES "+getTableName(project, fk.Table, removeProjectScope)+" ("+to+")")
+		}
+	}
+	return constraints
+}
+
+// sortedKeys returns the keys of the map in a deterministic order
+func sortedKeys(fks map[string]*foreignKeyStruct) []string {
+	keys := make([]string, 0, len(fks))
+	for key := range fks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func addCompositePrimaryKey(dbType, project, tableName string, fields []*SchemaFieldType, removeProjectScope bool) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.FieldName
+	}
+
+	switch utils.DBType(dbType) {
+	case utils.MySQL:
+		return "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " ADD PRIMARY KEY (" + strings.Join(names, ", ") + ")"
+	case utils.Postgres:
+		return "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " ADD CONSTRAINT " + primaryKeyConstraintName(tableName, fields) + " PRIMARY KEY (" + strings.Join(names, ", ") + ")"
+	case utils.SqlServer:
+		return "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " ADD CONSTRAINT " + primaryKeyConstraintName(tableName, fields) + " PRIMARY KEY CLUSTERED (" + strings.Join(names, ", ") + ")"
+	}
+	return ""
+}
+
+func removeCompositePrimaryKey(dbType, project, tableName string, fields []*SchemaFieldType, removeProjectScope bool) string {
+	switch utils.DBType(dbType) {
+	case utils.MySQL:
+		return "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " DROP PRIMARY KEY"
+	case utils.Postgres, utils.SqlServer:
+		return "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " DROP CONSTRAINT " + primaryKeyConstraintName(tableName, fields)
+	}
+	return ""
+}
+
+func addCompositeForeignKey(project, tableName string, fk *foreignKeyStruct, removeProjectScope bool) string {
+	from, to := fk.columns()
+	return "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " ADD CONSTRAINT " + foreignKeyConstraintName(tableName, fk) + " FOREIGN KEY (" + from + ") REFERENCES " + getTableName(project, fk.Table, removeProjectScope) + " (" + to + ")"
+}
+
+func removeCompositeForeignKey(dbType, project, tableName string, fk *foreignKeyStruct, removeProjectScope bool) []string {
+	name := foreignKeyConstraintName(tableName, fk)
+	switch utils.DBType(dbType) {
+	case utils.MySQL:
+		return []string{"ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " DROP FOREIGN KEY " + name, "ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " DROP INDEX " + name}
+	case utils.Postgres, utils.SqlServer:
+		return []string{"ALTER TABLE " + getTableName(project, tableName, removeProjectScope) + " DROP CONSTRAINT " + name}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package schema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestGetTableKeys(t *testing.T) {
+	var tests = []struct {
+		name, schema string
+		primary      []string
+		foreign      map[string]string
+		wantErr      bool
+	}{
+		{
+			name:    "single keys are not returned",
+			schema:  `type table1 { id: ID! @primary parent: ID @foreign(table: "table2", field: "id") }`,
+			foreign: map[string]string{},
+		},
+		{
+			name:    "primary key ordered as declared",
+			schema:  `type table1 { b: ID! @primary a: ID! @primary c: String }`,
+			primary: []string{"b", "a"},
+			foreign: map[string]string{},
+		},
+		{
+			name:    "primary key with order",
+			schema:  `type table1 { b: ID! @primary(order: 2) a: ID! @primary(order: 1) c: String }`,
+			primary: []string{"a", "b"},
+			foreign: map[string]string{},
+		},
+		{
+			name:    "primary key with invalid order",
+			schema:  `type table1 { b: ID! @primary(order: 3) a: ID! @primary(order: 1) c: String }`,
+			wantErr: true,
+		},
+		{
+			name:    "foreign key",
+			schema:  `type table1 { id: ID! @primary x: ID @foreign(table: "table2", field: "b", group: "g", order: 2) y: ID @foreign(table: "table2", field: "a", group: "g", order: 1) }`,
+			foreign: map[string]string{"g": "table2,y:a,x:b"},
+		},
+		{
+			name:    "foreign key referencing different tables",
+			schema:  `type table1 { id: ID! @primary x: ID @foreign(table: "table2", group: "g", order: 1) y: ID @foreign(table: "table3", group: "g", order: 2) }`,
+			wantErr: true,
+		},
+	}
+
+	s := Init(crud.Init(false), false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsedSchema, err := s.parser(config.Crud{"sqlite": &config.CrudStub{Collections: map[string]*config.TableRule{"table1": {Schema: tt.schema}}}})
+			if err != nil {
+				t.Fatal("could not parse schema:", err)
+			}
+
+			keys, err := getTableKeys(parsedSchema["sqlite"]["table1"])
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getTableKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var primary []string
+			for _, field := range keys.primary {
+				primary = append(primary, field.FieldName)
+			}
+			if strings.Join(primary, ",") != strings.Join(tt.primary, ",") {
+				t.Errorf("getTableKeys() primary key = %v; want %v", primary, tt.primary)
+			}
+
+			foreign := map[string]string{}
+			for group, fk := range keys.foreign {
+				foreign[group] = fk.signature()
+			}
+			if len(foreign) != len(tt.foreign) {
+				t.Errorf("getTableKeys() foreign keys = %v; want %v", foreign, tt.foreign)
+			}
+			for group, signature := range tt.foreign {
+				if foreign[group] != signature {
+					t.Errorf("getTableKeys() foreign keys = %v; want %v", foreign, tt.foreign)
+				}
+			}
+		})
+	}
+}
+
+func Test_removeCompositePrimaryKey(t *testing.T) {
+	var tests = []struct {
+		name   string
+		dbType string
+		fields []*SchemaFieldType
+		want   string
+	}{
+		{
+			name:   "key created by space cloud",
+			dbType: "postgres",
+			fields: []*SchemaFieldType{{FieldName: "org", PrimaryKeyInfo: &TableProperties{Order: 1}}, {FieldName: "id", PrimaryKeyInfo: &TableProperties{Order: 2}}},
+			want:   "ALTER TABLE project.users DROP CONSTRAINT c_users_id_org",
+		},
+		{
+			name:   "inspected key of a legacy table",
+			dbType: "postgres",
+			fields: []*SchemaFieldType{{FieldName: "org", PrimaryKeyInfo: &TableProperties{Order: 1, ConstraintName: "users_pkey"}}, {FieldName: "id", PrimaryKeyInfo: &TableProperties{Order: 2, ConstraintName: "users_pkey"}}},
+			want:   "ALTER TABLE project.users DROP CONSTRAINT users_pkey",
+		},
+		{
+			name:   "inspected key on sql server",
+			dbType: "sqlserver",
+			fields: []*SchemaFieldType{{FieldName: "org", PrimaryKeyInfo: &TableProperties{Order: 1, ConstraintName: "PK__users__3213E83F"}}, {FieldName: "id", PrimaryKeyInfo: &TableProperties{Order: 2, ConstraintName: "PK__users__3213E83F"}}},
+			want:   "ALTER TABLE project.users DROP CONSTRAINT PK__users__3213E83F",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := removeCompositePrimaryKey(tt.dbType, "project", "users", tt.fields, false); got != tt.want {
+				t.Errorf("removeCompositePrimaryKey() = %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_CompositeKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-keys")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: map[string]*config.TableRule{}}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	tables := map[string]*config.TableRule{
+		"users": {Schema: `type users { org: ID! @primary id: ID! @primary name: String }`},
+		"teams": {Schema: `type teams { id: ID! @primary name: String }`},
+		"user_groups": {Schema: `type user_groups {
+			user_org: ID! @primary(order: 1) @foreign(table: "users", field: "org", group: "user", order: 1)
+			user_id: ID! @primary(order: 2) @foreign(table: "users", field: "id", group: "user", order: 2)
+			team_id: ID! @primary(order: 3) @foreign(table: "teams", field: "id")
+		}`},
+	}
+
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the tables:", err)
+	}
+	queries := strings.Join(plan["user_groups"], "\n")
+	for _, want := range []string{
+		"CONSTRAINT c_user_groups_team_id_user_id_user_org PRIMARY KEY (user_org, user_id, team_id)",
+		"CONSTRAINT c_user_groups_user FOREIGN KEY (user_org, user_id) REFERENCES users (org, id)",
+		"team_id varchar(50) NOT NULL REFERENCES teams (id)",
+	} {
+		if !strings.Contains(queries, want) {
+			t.Errorf("PlanSchemaModifyAll() = %v; want it to contain %s", queries, want)
+		}
+	}
+
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not create the tables:", err)
+	}
+
+	// Nothing needs to be changed once the tables are created
+	plan, err = s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the tables again:", err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("PlanSchemaModifyAll() = %v; want no queries for the created tables", plan)
+	}
+
+	sdl, err := s.SchemaInspection(ctx, "sqlite", "project", "user_groups")
+	if err != nil {
+		t.Fatal("could not inspect the table:", err)
+	}
+	for _, want := range []string{
+		`user_org: ID! @primary(order: 1)`,
+		`@foreign(table: users, field: org, group: "user_org", order: 1)`,
+		`@foreign(table: users, field: id, group: "user_org", order: 2)`,
+		`@foreign(table: teams, field: id)`,
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SchemaInspection() = %s; want it to contain %s", sdl, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// migrationsSchema is the schema of the table in which the migrations are recorded
+const migrationsSchema = `type ` + utils.TableMigrations + ` {
+	id: ID! @primary
+	col: String!
+	statements: String!
+	applied_at: DateTime!
+	applied_by: String
+	previous_schema: String
+}`
+
+// Migration is a schema change applied to a table
+type Migration struct {
+	ID             string    `json:"id"`
+	Col            string    `json:"col"`
+	Statements     []string  `json:"statements"`
+	AppliedAt      time.Time `json:"appliedAt"`
+	AppliedBy      string    `json:"appliedBy"`
+	PreviousSchema string    `json:"previousSchema"` // The schema (in SDL) of the table before the migration
+}
+
+// applyMigration applies the queries to a table and records them in the migrations table. The migration is recorded
+// in the same transaction as the queries where the database allows it. It must be called with the lock held
+func (s *Schema) applyMigration(ctx context.Context, dbAlias, project, col, appliedBy string, queries []string) error {
+	if err := s.crud.CreateProjectIfNotExists(ctx, project, dbAlias); err != nil {
+		return err
+	}
+	if err := s.createMigrationsTable(ctx, dbAlias, project); err != nil {
+		return err
+	}
+
+	var previousSchema string
+	if dbStub, p := s.config[dbAlias]; p && dbStub != nil {
+		if rule, p := dbStub.Collections[col]; p && rule != nil {
+			previousSchema = rule.Schema
+		}
+	}
+
+	statements, err := json.Marshal(queries)
+	if err != nil {
+		return err
+	}
+
+	doc := map[string]interface{}{
+		"id":              ksuid.New().String(),
+		"col":             col,
+		"statements":      string(statements),
+		"applied_at":      time.Now().UTC(),
+		"applied_by":      appliedBy,
+		"previous_schema": previousSchema,
+	}
+	return s.crud.ApplyMigration(ctx, dbAlias, project, utils.TableMigrations, queries, doc)
+}
+
+// createMigrationsTable creates the migrations table if it doesn't exist already. The table is only checked for once
+// per database until the config changes
+func (s *Schema) createMigrationsTable(ctx context.Context, dbAlias, project string) error {
+	key := dbAlias + "." + project
+	if _, p := s.migrationTables.Load(key); p {
+		return nil
+	}
+
+	parsedSchema, err := s.parser(config.Crud{dbAlias: &config.CrudStub{
+		Collections: map[string]*config.TableRule{utils.TableMigrations: {Schema: migrationsSchema}},
+	}})
+	if err != nil {
+		return err
+	}
+
+	if err := s.SchemaCreation(ctx, dbAlias, utils.TableMigrations, project, parsedSchema); err != nil {
+		return err
+	}
+	s.migrationTables.Store(key, true)
+	return nil
+}
+
+// GetMigrations returns the migrations applied to the tables of a sql database, the most recent one first. Only the
+// migrations of the table are returned if col is provided
+func (s *Schema) GetMigrations(ctx context.Context, dbAlias, project, col string) ([]*Migration, error) {
+	migrations := []*Migration{}
+
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return nil, err
+	}
+	if dbType == string(utils.Mongo) {
+		return migrations, nil
+	}
+
+	// No migrations have been recorded if the migrations table doesn't exist
+	collections, err := s.crud.GetCollections(ctx, project, dbAlias)
+	if err != nil {
+		return nil, err
+	}
+	exists := false
+	for _, collection := range collections {
+		if collection.TableName == utils.TableMigrations {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		return migrations, nil
+	}
+
+	find := map[string]interface{}{}
+	if col != "" {
+		find["col"] = col
+	}
+	result, err := s.crud.InternalRead(ctx, dbAlias, project, utils.TableMigrations, &model.ReadRequest{
+		Find:      find,
+		Operation: utils.All,
+		Options:   &model.ReadOptions{Sort: []string{"-applied_at", "-id"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs, _ := result.([]interface{})
+	for _, doc := range docs {
+		if doc, ok := doc.(map[string]interface{}); ok {
+			migrations = append(migrations, toMigration(doc))
+		}
+	}
+	return migrations, nil
+}
+
+// toMigration converts a row of the migrations table to a migration
+func toMigration(doc map[string]interface{}) *Migration {
+	migration := new(Migration)
+	migration.ID, _ = doc["id"].(string)
+	migration.Col, _ = doc["col"].(string)
+	migration.AppliedBy, _ = doc["applied_by"].(string)
+	migration.PreviousSchema, _ = doc["previous_schema"].(string)
+
+	if statements, ok := doc["statements"].(string); ok {
+		_ = json.Unmarshal([]byte(statements), &migration.Statements)
+	}
+
+	// Some drivers return the timestamps as strings
+	switch v := doc["applied_at"].(type) {
+	case time.Time:
+		migration.AppliedAt = v
+	case string:
+		migration.AppliedAt, _ = time.Parse(time.RFC3339, v)
+	}
+	return migration
+}
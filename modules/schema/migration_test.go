@@ -0,0 +1,111 @@
+package schema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestSchema_Migrations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-migrations")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: map[string]*config.TableRule{}}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	tables := map[string]*config.TableRule{"todos": {Schema: "type todos { id: ID! @primary text: String }"}}
+
+	// A dry run only plans the queries
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the migration:", err)
+	}
+	want := []string{"CREATE TABLE todos (id varchar(50) PRIMARY KEY NOT NULL ,text text );"}
+	if !reflect.DeepEqual(plan["todos"], want) {
+		t.Errorf("PlanSchemaModifyAll() = %v; want %v", plan["todos"], want)
+	}
+	if migrations, err := s.GetMigrations(ctx, "sqlite", "project", ""); err != nil || len(migrations) != 0 {
+		t.Fatalf("GetMigrations() = (%v, %v); want no migrations before the first one is applied", migrations, err)
+	}
+
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not apply the migration:", err)
+	}
+
+	// Applying the same schema again does not record a migration
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not apply the migration again:", err)
+	}
+
+	migrations, err := s.GetMigrations(ctx, "sqlite", "project", "todos")
+	if err != nil {
+		t.Fatal("could not get migrations:", err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("got %d migrations; wanted 1", len(migrations))
+	}
+	if m := migrations[0]; !reflect.DeepEqual(m.Statements, want) || m.AppliedBy != "admin" || m.Col != "todos" || m.AppliedAt.IsZero() {
+		t.Errorf("got migration %+v; wanted the statements %v applied by admin", m, want)
+	}
+}
+
+func TestSchema_ModifySoftDeleteTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-migrations")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	stored := map[string]*config.TableRule{"todos": {Schema: "type todos { id: ID! @primary text: String }", SoftDelete: true}}
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: stored}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", stored); err != nil {
+		t.Fatal("could not create the table:", err)
+	}
+
+	// The request modifying the schema leaves out the soft delete flag, yet the column marking deleted documents stays
+	tables := map[string]*config.TableRule{"todos": {Schema: "type todos { id: ID! @primary text: String done: Boolean }"}}
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the migration:", err)
+	}
+	want := []string{"ALTER TABLE todos ADD COLUMN done boolean"}
+	if !reflect.DeepEqual(plan["todos"], want) {
+		t.Errorf("PlanSchemaModifyAll() = %v; want %v", plan["todos"], want)
+	}
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not apply the migration:", err)
+	}
+}
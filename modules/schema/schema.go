@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -25,11 +26,13 @@ type Schema struct {
 	project            string
 	config             config.Crud
 	removeProjectScope bool
+	stopRefreshes      context.CancelFunc // stops the scheduled refreshes of the materialized views
+	migrationTables    *sync.Map          // the databases in which the migrations table is known to exist
 }
 
 // Init creates a new instance of the schema object
 func Init(crud *crud.Module, removeProjectScope bool) *Schema {
-	return &Schema{SchemaDoc: schemaType{}, crud: crud, removeProjectScope: removeProjectScope}
+	return &Schema{SchemaDoc: schemaType{}, crud: crud, removeProjectScope: removeProjectScope, migrationTables: new(sync.Map)}
 }
 
 // SetConfig modifies the tables according to the schema on save
@@ -39,11 +42,13 @@ func (s *Schema) SetConfig(conf config.Crud, project string) error {
 
 	s.config = conf
 	s.project = project
+	s.migrationTables = new(sync.Map)
 
 	if err := s.parseSchema(conf); err != nil {
 		return err
 	}
 
+	s.scheduleViewRefreshes(conf, project)
 	return nil
 }
 
@@ -69,6 +74,19 @@ func (s *Schema) GetSchema(dbType, col string) (SchemaFields, bool) {
 	return fields, true
 }
 
+// GetVersionField returns the field declared with the version directive in the schema of the collection
+func (s *Schema) GetVersionField(dbAlias, col string) (string, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for fieldName, field := range s.SchemaDoc[dbAlias][col] {
+		if field.IsVersion {
+			return fieldName, true
+		}
+	}
+	return "", false
+}
+
 // parseSchema Initializes Schema field in Module struct
 func (s *Schema) parseSchema(crud config.Crud) error {
 	schema, err := s.parser(crud)
@@ -104,6 +122,13 @@ func (s *Schema) parser(crud config.Crud) (schemaType, error) {
 			if len(value) <= 1 { // schema might have an id by default
 				continue
 			}
+
+			// The documents of soft delete collections are marked as deleted in a field the schema needn't declare
+			if v.SoftDelete {
+				if _, p := value[utils.SoftDeleteField]; !p {
+					value[utils.SoftDeleteField] = &SchemaFieldType{FieldName: utils.SoftDeleteField, Kind: typeDateTime}
+				}
+			}
 			collection[strings.ToLower(collectionName[0:1])+collectionName[1:]] = value
 		}
 		schema[dbName] = collection
@@ -115,8 +140,14 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 	var isCollectionFound bool
 
 	fieldMap := SchemaFields{}
+	primaryKeys := []*SchemaFieldType{}
 	for _, v := range doc.Definitions {
-		colName := v.(*ast.ObjectDefinition).Name.Value
+		// Enums are loaded along with the fields using them
+		objectDefinition, ok := v.(*ast.ObjectDefinition)
+		if !ok {
+			continue
+		}
+		colName := objectDefinition.Name.Value
 
 		if colName != collectionName {
 			continue
@@ -125,7 +156,7 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 		// Mark the collection as found
 		isCollectionFound = true
 
-		for _, field := range v.(*ast.ObjectDefinition).Fields {
+		for _, field := range objectDefinition.Fields {
 
 			fieldTypeStuct := SchemaFieldType{
 				FieldName: field.Name.Value,
@@ -137,10 +168,28 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 					switch directive.Name.Value {
 					case directivePrimary:
 						fieldTypeStuct.IsPrimary = true
+						primaryKeys = append(primaryKeys, &fieldTypeStuct)
+						for _, arg := range directive.Arguments {
+							switch arg.Name.Value {
+							case "order":
+								val, _ := utils.ParseGraphqlValue(arg.Value, nil)
+								order, ok := val.(int)
+								if !ok {
+									return nil, fmt.Errorf("invalid variable type (%s) provided for %s in %s", reflect.TypeOf(val), arg.Name.Value, directivePrimary)
+								}
+								fieldTypeStuct.PrimaryKeyInfo = &TableProperties{Order: order}
+							}
+						}
 					case directiveCreatedAt:
 						fieldTypeStuct.IsCreatedAt = true
 					case directiveUpdatedAt:
 						fieldTypeStuct.IsUpdatedAt = true
+					case directiveVersion:
+						fieldTypeStuct.IsVersion = true
+					case directiveLength, directiveRange, directivePattern, directiveEmail:
+						if err := parseValidatorDirective(directive, &fieldTypeStuct); err != nil {
+							return nil, err
+						}
 					case directiveDefault:
 						fieldTypeStuct.IsDefault = true
 
@@ -219,8 +268,10 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 						fieldTypeStuct.JointTable.Table = strings.Split(field.Name.Value, "_")[0]
 						fieldTypeStuct.JointTable.To = "id"
 
-						// Load the joint table name and field
+						// Load the joint table name and field. The fields of a foreign key spanning multiple fields
+						// share the same group
 						for _, arg := range directive.Arguments {
+							var ok bool
 							switch arg.Name.Value {
 							case "table":
 								val, _ := utils.ParseGraphqlValue(arg.Value, nil)
@@ -229,6 +280,20 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 							case "field", "to":
 								val, _ := utils.ParseGraphqlValue(arg.Value, nil)
 								fieldTypeStuct.JointTable.To = val.(string)
+
+							case "name", "group":
+								val, _ := utils.ParseGraphqlValue(arg.Value, nil)
+								fieldTypeStuct.JointTable.Group, ok = val.(string)
+								if !ok {
+									return nil, fmt.Errorf("invalid variable type (%s) provided for %s in %s", reflect.TypeOf(val), arg.Name.Value, directiveForeign)
+								}
+
+							case "order":
+								val, _ := utils.ParseGraphqlValue(arg.Value, nil)
+								fieldTypeStuct.JointTable.Order, ok = val.(int)
+								if !ok {
+									return nil, fmt.Errorf("invalid variable type (%s) provided for %s in %s", reflect.TypeOf(val), arg.Name.Value, directiveForeign)
+								}
 							}
 						}
 					}
@@ -240,6 +305,14 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 				return nil, err
 			}
 			fieldTypeStuct.Kind = kind
+
+			// The version of a document is a counter
+			if fieldTypeStuct.IsVersion && (kind != typeInteger || fieldTypeStuct.IsList) {
+				return nil, fmt.Errorf("invalid type for field %s - version field must be an integer", fieldTypeStuct.FieldName)
+			}
+			if err := checkValidatorKind(&fieldTypeStuct); err != nil {
+				return nil, err
+			}
 			fieldMap[field.Name.Value] = &fieldTypeStuct
 		}
 	}
@@ -248,6 +321,15 @@ func getCollectionSchema(doc *ast.Document, dbName, collectionName string) (Sche
 	if !isCollectionFound {
 		return nil, fmt.Errorf("collection %s could not be found in schema", collectionName)
 	}
+
+	// The fields of a primary key spanning multiple fields are ordered as declared unless the order is provided
+	if len(primaryKeys) > 1 {
+		for i, field := range primaryKeys {
+			if field.PrimaryKeyInfo == nil {
+				field.PrimaryKeyInfo = &TableProperties{Order: i + 1}
+			}
+		}
+	}
 	return fieldMap, nil
 }
 
@@ -280,6 +362,8 @@ func getFieldType(dbName string, fieldType ast.Type, fieldTypeStuct *SchemaField
 			return typeInteger, nil
 		case typeBoolean:
 			return typeBoolean, nil
+		case typeJSON:
+			return typeJSON, nil
 		default:
 			if fieldTypeStuct.IsLinked {
 				// Since the field is actually a link. We'll store the type as is. This type must correspond to a table or a primitive type
@@ -287,6 +371,11 @@ func getFieldType(dbName string, fieldType ast.Type, fieldTypeStuct *SchemaField
 				return myType, nil
 			}
 
+			if enum := getEnumDefinition(doc, myType); enum != nil {
+				fieldTypeStuct.EnumInfo = enum
+				return typeEnum, nil
+			}
+
 			// The field is a nested type. Update the nestedObject field and return typeObject. This is a side effect.
 			nestedschemaField, err := getCollectionSchema(doc, dbName, myType)
 			if err != nil {
@@ -300,3 +389,20 @@ func getFieldType(dbName string, fieldType ast.Type, fieldTypeStuct *SchemaField
 		return "", fmt.Errorf("invalid field kind `%s` provided for field `%s`", fieldType.GetKind(), fieldTypeStuct.FieldName)
 	}
 }
+
+// getEnumDefinition returns the enum with the provided name defined in the schema
+func getEnumDefinition(doc *ast.Document, name string) *EnumProperties {
+	for _, v := range doc.Definitions {
+		enumDefinition, ok := v.(*ast.EnumDefinition)
+		if !ok || enumDefinition.Name.Value != name {
+			continue
+		}
+
+		enum := &EnumProperties{Name: name, Values: make([]string, len(enumDefinition.Values))}
+		for i, value := range enumDefinition.Values {
+			enum.Values[i] = value.Name.Value
+		}
+		return enum
+	}
+	return nil
+}
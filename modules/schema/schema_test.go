@@ -1,11 +1,17 @@
 package schema
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
 )
 
 func TestParseSchema(t *testing.T) {
@@ -149,3 +155,56 @@ func TestParseSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestSchema_SoftDeleteField(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-soft-delete")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tables := map[string]*config.TableRule{"todos": {Schema: "type todos { id: ID! @primary text: String }", SoftDelete: true}}
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: tables}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{
+		Delete: func(ctx context.Context, dbAlias, col string, req *model.DeleteRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Stage: func(ctx context.Context, intent *model.EventIntent, err error) {},
+	}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	// The soft delete field is created even though the schema doesn't declare it
+	ctx := context.Background()
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not create the table:", err)
+	}
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the table again:", err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("PlanSchemaModifyAll() = %v; want no queries for the created table", plan)
+	}
+
+	if err := c.InternalCreate(ctx, "sqlite", "project", "todos", &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"id": "1", "text": "a"}}); err != nil {
+		t.Fatal("could not create the todo:", err)
+	}
+	if err := c.Delete(ctx, "sqlite", "project", "todos", &model.DeleteRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}}); err != nil {
+		t.Fatal("could not delete the todo:", err)
+	}
+	result, _, err := c.Read(ctx, "sqlite", "project", "todos", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{}})
+	if err != nil {
+		t.Fatal("could not count the todos:", err)
+	}
+	if result != int64(0) {
+		t.Errorf("Read() count = %v; want the deleted todo left out", result)
+	}
+}
@@ -0,0 +1,129 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// Fixture holds the documents to be seeded in a collection
+type Fixture struct {
+	Col  string                   `json:"col" yaml:"col"`
+	Docs []map[string]interface{} `json:"docs" yaml:"docs"`
+}
+
+// ParseFixtures parses the fixtures provided in json or yaml. The fixtures are a list so that the collections are
+// seeded in the order they are provided in (e.g. the referenced collections first)
+func ParseFixtures(data []byte, format string) ([]*Fixture, error) {
+	var fixtures []*Fixture
+	switch format {
+	case "json":
+		if err := json.Unmarshal(data, &fixtures); err != nil {
+			return nil, err
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &fixtures); err != nil {
+			return nil, err
+		}
+		// Yaml decodes the nested objects with keys of any type
+		for _, fixture := range fixtures {
+			for i, doc := range fixture.Docs {
+				fixture.Docs[i] = normaliseYAML(doc).(map[string]interface{})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid format (%s) provided for fixtures - wanted json or yaml", format)
+	}
+
+	for i, fixture := range fixtures {
+		if fixture == nil || fixture.Col == "" {
+			return nil, fmt.Errorf("collection not provided for fixture (%d)", i)
+		}
+	}
+	return fixtures, nil
+}
+
+func normaliseYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			obj[fmt.Sprintf("%v", key)] = normaliseYAML(val)
+		}
+		return obj
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = normaliseYAML(val)
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normaliseYAML(val)
+		}
+		return v
+	}
+	return value
+}
+
+// Seed upserts the documents of the fixtures in a single batch. The documents are validated against the schema and
+// upserted on the primary key of their collection, so seeding the same fixtures again does not duplicate them. It
+// returns the number of documents seeded in each collection
+func (s *Schema) Seed(ctx context.Context, dbAlias, project string, fixtures []*Fixture) (map[string]int, error) {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	req := &model.BatchRequest{}
+	for _, fixture := range fixtures {
+		keys := s.GetPrimaryKeys(dbAlias, fixture.Col)
+		if len(keys) == 0 {
+			if dbType != string(utils.Mongo) {
+				return nil, fmt.Errorf("collection (%s) has no primary key to seed the documents on", fixture.Col)
+			}
+			keys = []string{"_id"}
+		}
+
+		// The primary key must be provided since a generated one would create a new document on every seed
+		docs := make([]interface{}, len(fixture.Docs))
+		for i, doc := range fixture.Docs {
+			for _, key := range keys {
+				if _, p := doc[key]; !p {
+					return nil, fmt.Errorf("document (%d) of collection (%s) does not have the primary key field (%s)", i, fixture.Col, key)
+				}
+			}
+			docs[i] = doc
+		}
+		createReq := &model.CreateRequest{Document: docs, Operation: utils.All}
+		if err := s.ValidateCreateOperation(dbAlias, fixture.Col, createReq); err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docs {
+			doc := doc.(map[string]interface{})
+			find := make(map[string]interface{}, len(keys))
+			for _, key := range keys {
+				find[key] = doc[key]
+			}
+			req.Requests = append(req.Requests, model.AllRequest{Type: string(utils.Update), Col: fixture.Col, Operation: utils.Upsert, Find: find, Update: map[string]interface{}{"$set": doc}})
+		}
+		counts[fixture.Col] += len(docs)
+	}
+
+	if len(req.Requests) == 0 {
+		return counts, nil
+	}
+	if err := s.crud.CreateProjectIfNotExists(ctx, project, dbAlias); err != nil {
+		return nil, err
+	}
+	if err := s.crud.Batch(ctx, dbAlias, project, req); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
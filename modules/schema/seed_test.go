@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func TestParseFixtures(t *testing.T) {
+	want := []*Fixture{{Col: "todos", Docs: []map[string]interface{}{{"id": "1", "meta": map[string]interface{}{"tags": []interface{}{"a"}}}}}}
+
+	var tests = []struct {
+		name, format, data string
+		wantErr            bool
+	}{
+		{name: "yaml", format: "yaml", data: "- col: todos\n  docs:\n    - id: \"1\"\n      meta:\n        tags: [a]\n"},
+		{name: "json", format: "json", data: `[{"col": "todos", "docs": [{"id": "1", "meta": {"tags": ["a"]}}]}]`},
+		{name: "missing collection", format: "json", data: `[{"docs": [{"id": "1"}]}]`, wantErr: true},
+		{name: "invalid format", format: "csv", data: "id\n1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFixtures([]byte(tt.data), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFixtures() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseFixtures() = %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSchema_Seed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-seed")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tables := map[string]*config.TableRule{"todos": {Schema: "type todos { id: ID! @primary text: String! done: Boolean }"}}
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: tables}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{
+		Batch: func(ctx context.Context, dbType string, req *model.BatchRequest) (*model.EventIntent, error) {
+			return &model.EventIntent{Invalid: true}, nil
+		},
+		Stage: func(ctx context.Context, intent *model.EventIntent, err error) {},
+	}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	c.SetSchema(s)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not create the table:", err)
+	}
+
+	seed := func(text string) {
+		fixtures := []*Fixture{{Col: "todos", Docs: []map[string]interface{}{{"id": "1", "text": text}, {"id": "2", "text": "b", "done": true}}}}
+		counts, err := s.Seed(ctx, "sqlite", "project", fixtures)
+		if err != nil {
+			t.Fatal("could not seed the fixtures:", err)
+		}
+		if counts["todos"] != 2 {
+			t.Errorf("Seed() = %v; want 2 documents seeded in todos", counts)
+		}
+	}
+
+	// Seeding again updates the documents instead of duplicating them
+	seed("a")
+	seed("c")
+
+	result, err := c.InternalRead(ctx, "sqlite", "project", "todos", &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{"id": "1"}})
+	if err != nil {
+		t.Fatal("could not read the todos:", err)
+	}
+	if docs, ok := result.([]interface{}); !ok || len(docs) != 1 || docs[0].(map[string]interface{})["text"] != "c" {
+		t.Errorf("InternalRead() = %v; want the todo updated by the second seed", result)
+	}
+	count, err := c.InternalRead(ctx, "sqlite", "project", "todos", &model.ReadRequest{Operation: utils.Count, Find: map[string]interface{}{}})
+	if err != nil {
+		t.Fatal("could not count the todos:", err)
+	}
+	if count != int64(2) {
+		t.Errorf("InternalRead() count = %v; want 2", count)
+	}
+
+	// The documents are validated against the schema and must have their primary key
+	for _, doc := range []map[string]interface{}{{"id": "3"}, {"text": "d"}} {
+		if _, err := s.Seed(ctx, "sqlite", "project", []*Fixture{{Col: "todos", Docs: []map[string]interface{}{doc}}}); err == nil {
+			t.Errorf("Seed() succeeded for the invalid document %v", doc)
+		}
+	}
+}
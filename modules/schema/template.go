@@ -2,18 +2,51 @@ package schema
 
 import (
 	"bytes"
+	"sort"
 	"text/template"
 )
 
+// sdlTemplate renders the types of the collections followed by the types of their nested objects and their enums
+const sdlTemplate = `{{define "fields"}}{{range $fieldName, $fieldValue := .}}
+	{{$fieldName}}: {{if $fieldValue.IsList}}[{{end}}{{if eq $fieldValue.Kind "Object"}}{{$fieldValue.JointTable.Table}}{{else if eq $fieldValue.Kind "Enum"}}{{$fieldValue.EnumInfo.Name}}{{else}}{{$fieldValue.Kind}}{{end}}{{if $fieldValue.IsList}}]{{end}}{{if $fieldValue.IsFieldTypeRequired}}!{{end}} {{if $fieldValue.IsPrimary}}@primary{{if $fieldValue.PrimaryKeyInfo}}(order: {{$fieldValue.PrimaryKeyInfo.Order}}){{end}}{{end}} {{if $fieldValue.IsUnique}}@unique(group: "{{$fieldValue.IndexInfo.Group}}", order: {{$fieldValue.IndexInfo.Order}})  {{else}} {{if $fieldValue.IsIndex}}@index(group: "{{$fieldValue.IndexInfo.Group}}", sort: "{{$fieldValue.IndexInfo.Sort}}", order: {{$fieldValue.IndexInfo.Order}}){{end}}{{end}} {{if $fieldValue.IsDefault}}@default(value: {{$fieldValue.Default}}){{end}} {{if $fieldValue.IsVersion}}@version {{end}} {{if $fieldValue.Validators}}{{validators $fieldValue.Validators}}{{end}} {{if $fieldValue.IsForeign}}@foreign(table: {{$fieldValue.JointTable.Table}}, field: {{$fieldValue.JointTable.To}}{{if $fieldValue.JointTable.Group}}, group: "{{$fieldValue.JointTable.Group}}", order: {{$fieldValue.JointTable.Order}}{{end}}){{end}}{{end}}{{end}}type {{range $k,$v := .Types}} {{$k}} { {{template "fields" $v}}
+}{{end}}{{range .Nested}}
+type {{.Name}} { {{template "fields" .Fields}}
+}{{end}}{{range $k,$v := .Types}}{{range $fieldName, $fieldValue := $v}}{{if eq $fieldValue.Kind "Enum"}}
+enum {{$fieldValue.EnumInfo.Name}} { {{range $fieldValue.EnumInfo.Values}}{{.}} {{end}}}{{end}}{{end}}{{end}}`
+
+// nestedType is the type of a nested object in the schema
+type nestedType struct {
+	Name   string
+	Fields SchemaFields
+}
+
 func generateSDL(schemaCol schemaCollection) (string, error) {
-	schema := `type {{range $k,$v := .}} {{$k}} { {{range $fieldName, $fieldValue := $v}}
-	{{$fieldName}}: {{if eq $fieldValue.Kind "Object"}}{{$fieldValue.JointTable.Table}}{{else}}{{$fieldValue.Kind}}{{end}}{{if $fieldValue.IsFieldTypeRequired}}!{{end}} {{if $fieldValue.IsPrimary}}@primary{{end}} {{if $fieldValue.IsUnique}}@unique(group: "{{$fieldValue.IndexInfo.Group}}", order: {{$fieldValue.IndexInfo.Order}})  {{else}} {{if $fieldValue.IsIndex}}@index(group: "{{$fieldValue.IndexInfo.Group}}", sort: "{{$fieldValue.IndexInfo.Sort}}", order: {{$fieldValue.IndexInfo.Order}}){{end}}{{end}} {{if $fieldValue.IsDefault}}@default(value: {{$fieldValue.Default}}){{end}} {{if $fieldValue.IsForeign}}@foreign(table: {{$fieldValue.JointTable.Table}}, field: {{$fieldValue.JointTable.To}}){{end}}{{end}}{{end}}
-}`
+	data := struct {
+		Types  schemaCollection
+		Nested []nestedType
+	}{Types: schemaCol}
+	for _, fields := range schemaCol {
+		data.Nested = append(data.Nested, nestedTypes(fields)...)
+	}
+	sort.Slice(data.Nested, func(i, j int) bool { return data.Nested[i].Name < data.Nested[j].Name })
 
 	buf := &bytes.Buffer{}
-	t := template.Must(template.New("greet").Parse(schema))
-	if err := t.Execute(buf, schemaCol); err != nil {
+	t := template.Must(template.New("greet").Funcs(template.FuncMap{"validators": validatorDirectives}).Parse(sdlTemplate))
+	if err := t.Execute(buf, data); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
 }
+
+// nestedTypes returns the types of the nested objects of the fields (including the objects nested in them)
+func nestedTypes(fields SchemaFields) []nestedType {
+	var types []nestedType
+	for _, field := range fields {
+		if field.Kind != typeObject || field.nestedObject == nil || field.JointTable == nil {
+			continue
+		}
+		types = append(types, nestedType{Name: field.JointTable.Table, Fields: field.nestedObject})
+		types = append(types, nestedTypes(field.nestedObject)...)
+	}
+	return types
+}
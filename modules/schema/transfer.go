@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// GetFieldNames returns the sorted names of the fields stored in the collection. Linked fields are left out since
+// they aren't stored in the collection
+func (s *Schema) GetFieldNames(dbAlias, col string) ([]string, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	colSchema, p := s.SchemaDoc[dbAlias][col]
+	if !p {
+		return nil, false
+	}
+
+	names := make([]string, 0, len(colSchema))
+	for name, field := range colSchema {
+		if !field.IsLinked {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// GetPrimaryKeys returns the sorted names of the fields forming the primary key of the collection
+func (s *Schema) GetPrimaryKeys(dbAlias, col string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var keys []string
+	for name, field := range s.SchemaDoc[dbAlias][col] {
+		if field.IsPrimary {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseStringValues converts the string values of a document read from a text format like csv to the types of the
+// fields in the schema. Objects and lists are expected to be provided as JSON
+func (s *Schema) ParseStringValues(dbAlias, col string, doc map[string]interface{}) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	colSchema, p := s.SchemaDoc[dbAlias][col]
+	if !p {
+		return nil
+	}
+
+	for name, value := range doc {
+		str, ok := value.(string)
+		field, p := colSchema[name]
+		if !ok || !p {
+			continue
+		}
+
+		var v interface{}
+		var err error
+		switch {
+		case field.IsList || field.Kind == typeObject || field.Kind == typeJSON:
+			err = json.Unmarshal([]byte(str), &v)
+		case field.Kind == typeInteger:
+			v, err = strconv.Atoi(str)
+		case field.Kind == typeFloat:
+			v, err = strconv.ParseFloat(str, 64)
+		case field.Kind == typeBoolean:
+			v, err = strconv.ParseBool(str)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value provided for field (%s) - %v", name, err)
+		}
+		doc[name] = v
+	}
+	return nil
+}
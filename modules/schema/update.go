@@ -28,6 +28,9 @@ func (s *Schema) ValidateUpdateOperation(dbType, col, op string, updateDoc, find
 			if err != nil {
 				return err
 			}
+			if err := s.formatSQLValues(dbType, SchemaDoc, newDoc.(map[string]interface{})); err != nil {
+				return err
+			}
 			updateDoc[key] = newDoc
 		case "$push":
 			err := s.validateArrayOperations(col, doc, SchemaDoc)
@@ -35,7 +38,7 @@ func (s *Schema) ValidateUpdateOperation(dbType, col, op string, updateDoc, find
 				return err
 			}
 		case "$inc", "$min", "$max", "$mul":
-			if err := validateMathOperations(col, doc, SchemaDoc); err != nil {
+			if err := validateMathOperations(col, key, doc, SchemaDoc); err != nil {
 				return err
 			}
 		case "$currentDate":
@@ -91,13 +94,21 @@ func (s *Schema) validateArrayOperations(col string, doc interface{}, SchemaDoc
 				return fmt.Errorf("invalid type provided for field %s in collection %s", fieldKey, col)
 			}
 			for _, value := range t {
-				if _, err := s.checkType(col, value, SchemaDocValue); err != nil {
+				val, err := s.checkType(col, value, SchemaDocValue)
+				if err != nil {
+					return err
+				}
+				if err := checkValidators(col, val, SchemaDocValue); err != nil {
 					return err
 				}
 			}
 			return nil
 		case interface{}:
-			if _, err := s.checkType(col, t, SchemaDocValue); err != nil {
+			val, err := s.checkType(col, t, SchemaDocValue)
+			if err != nil {
+				return err
+			}
+			if err := checkValidators(col, val, SchemaDocValue); err != nil {
 				return err
 			}
 		default:
@@ -108,7 +119,11 @@ func (s *Schema) validateArrayOperations(col string, doc interface{}, SchemaDoc
 	return nil
 }
 
-func validateMathOperations(col string, doc interface{}, SchemaDoc SchemaFields) error {
+// validateMathOperations checks the types of the operands of the math operators. The operands of $min and $max are
+// checked against the validators of their fields as well, since the operand becomes the value of the field if it is
+// out of range. The result of $inc and $mul depends on the value stored, hence it can only be kept in range by the
+// check constraint of the field, which isn't created on mysql and mongo
+func validateMathOperations(col, op string, doc interface{}, SchemaDoc SchemaFields) error {
 
 	v, ok := doc.(map[string]interface{})
 	if !ok {
@@ -127,15 +142,19 @@ func validateMathOperations(col string, doc interface{}, SchemaDoc SchemaFields)
 			if schemaDocValue.Kind != typeInteger && schemaDocValue.Kind != typeFloat {
 				return fmt.Errorf("invalid type received for field %s in collection %s - wanted %s got Integer", fieldKey, col, schemaDocValue.Kind)
 			}
-			return nil
 		case float32, float64:
 			if schemaDocValue.Kind != typeFloat {
 				return fmt.Errorf("invalid type received for field %s in collection %s - wanted %s got Float", fieldKey, col, schemaDocValue.Kind)
 			}
-			return nil
 		default:
 			return fmt.Errorf("invalid type received for field %s in collection %s - wanted %s", fieldKey, col, schemaDocValue.Kind)
 		}
+
+		if op == "$min" || op == "$max" {
+			if err := checkValidators(col, fieldValue, schemaDocValue); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -181,6 +200,9 @@ func (s *Schema) validateSetOperation(col string, doc interface{}, SchemaDoc Sch
 		if err != nil {
 			return nil, err
 		}
+		if err := checkValidators(col, newDoc, SchemaDocValue); err != nil {
+			return nil, err
+		}
 		newMap[key] = newDoc
 	}
 
@@ -1,10 +1,12 @@
 package schema
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/segmentio/ksuid"
 
 	"github.com/spaceuptech/space-cloud/model"
@@ -30,6 +32,12 @@ func (s *Schema) schemaValidator(col string, collectionFields SchemaFields, doc
 			ok = true
 		}
 
+		// Documents start with the first version
+		if !ok && fieldValue.IsVersion {
+			value = 1
+			ok = true
+		}
+
 		if fieldValue.Kind == TypeID && !ok {
 			value = ksuid.New().String()
 			ok = true
@@ -51,6 +59,9 @@ func (s *Schema) schemaValidator(col string, collectionFields SchemaFields, doc
 		if err != nil {
 			return nil, err
 		}
+		if err := checkValidators(col, val, fieldValue); err != nil {
+			return nil, err
+		}
 
 		mutatedDoc[fieldKey] = val
 	}
@@ -91,6 +102,9 @@ func (s *Schema) ValidateCreateOperation(dbType, col string, req *model.CreateRe
 		if err != nil {
 			return err
 		}
+		if err := s.formatSQLValues(dbType, collectionFields, newDoc); err != nil {
+			return err
+		}
 
 		v[index] = newDoc
 	}
@@ -102,6 +116,14 @@ func (s *Schema) ValidateCreateOperation(dbType, col string, req *model.CreateRe
 }
 func (s *Schema) checkType(col string, value interface{}, fieldValue *SchemaFieldType) (interface{}, error) {
 
+	// JSON fields can hold any value. The values of a list of JSON fields must be an array though
+	if fieldValue.Kind == typeJSON {
+		if _, ok := value.([]interface{}); fieldValue.IsList && !ok && value != nil {
+			return nil, fmt.Errorf("invalid type received for field %s in collection %s - wanted an array", fieldValue.FieldName, col)
+		}
+		return value, nil
+	}
+
 	switch v := value.(type) {
 	case int:
 		// TODO: int64
@@ -126,6 +148,13 @@ func (s *Schema) checkType(col string, value interface{}, fieldValue *SchemaFiel
 			return unitTimeInRFC3339, nil
 		case TypeID, typeString:
 			return value, nil
+		case typeEnum:
+			for _, enumValue := range fieldValue.EnumInfo.Values {
+				if v == enumValue {
+					return value, nil
+				}
+			}
+			return nil, fmt.Errorf("invalid value (%s) received for field %s in collection %s - wanted one of %v", v, fieldValue.FieldName, col, fieldValue.EnumInfo.Values)
 		default:
 			return nil, fmt.Errorf("invalid type received for field %s in collection %s - wanted %s got String", fieldValue.FieldName, col, fieldValue.Kind)
 		}
@@ -178,3 +207,35 @@ func (s *Schema) checkType(col string, value interface{}, fieldValue *SchemaFiel
 		return nil, fmt.Errorf("no matching type found for field %s in collection %s", fieldValue.FieldName, col)
 	}
 }
+
+// formatSQLValues converts the values of the JSON and list fields of a document to the format in which they are
+// stored by sql databases. Postgres stores lists as arrays while the other databases store them as JSON
+func (s *Schema) formatSQLValues(dbAlias string, fields SchemaFields, doc map[string]interface{}) error {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil || dbType == string(utils.Mongo) {
+		return nil
+	}
+
+	for name, value := range doc {
+		field, p := fields[name]
+		if !p || value == nil || field.IsLinked || !(field.IsList || field.Kind == typeJSON) {
+			continue
+		}
+
+		if field.IsList && field.Kind != typeJSON && dbType == string(utils.Postgres) {
+			v, err := pq.Array(value).Value()
+			if err != nil {
+				return fmt.Errorf("invalid value provided for field (%s) - %v", name, err)
+			}
+			doc[name] = v
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("invalid value provided for field (%s) - %v", name, err)
+		}
+		doc[name] = string(data)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// emailPattern is the pattern the values of the fields with the email directive must match
+const emailPattern = `^[^\s@]+@[^\s@]+\.[^\s@]+$`
+
+var emailRegex = regexp.MustCompile(emailPattern)
+
+// parseValidatorDirective loads the arguments of the length, range, pattern and email directives in the validators
+// of the field
+func parseValidatorDirective(directive *ast.Directive, fieldTypeStuct *SchemaFieldType) error {
+	if fieldTypeStuct.Validators == nil {
+		fieldTypeStuct.Validators = &ValidatorProperties{}
+	}
+	v := fieldTypeStuct.Validators
+
+	switch directive.Name.Value {
+	case directiveLength:
+		for _, arg := range directive.Arguments {
+			val, _ := utils.ParseGraphqlValue(arg.Value, nil)
+			length, ok := val.(int)
+			if !ok || length < 0 {
+				return fmt.Errorf("invalid value (%v) provided for %s in %s", val, arg.Name.Value, directiveLength)
+			}
+			switch arg.Name.Value {
+			case "min":
+				v.MinLength = &length
+			case "max":
+				v.MaxLength = &length
+			}
+		}
+		if v.MinLength == nil && v.MaxLength == nil {
+			return fmt.Errorf("%s directive must be accompanied with min or max field", directiveLength)
+		}
+
+	case directiveRange:
+		for _, arg := range directive.Arguments {
+			val, _ := utils.ParseGraphqlValue(arg.Value, nil)
+			var number float64
+			switch n := val.(type) {
+			case int:
+				number = float64(n)
+			case float64:
+				number = n
+			default:
+				return fmt.Errorf("invalid variable type (%s) provided for %s in %s", reflect.TypeOf(val), arg.Name.Value, directiveRange)
+			}
+			switch arg.Name.Value {
+			case "min":
+				v.Min = &number
+			case "max":
+				v.Max = &number
+			}
+		}
+		if v.Min == nil && v.Max == nil {
+			return fmt.Errorf("%s directive must be accompanied with min or max field", directiveRange)
+		}
+
+	case directivePattern:
+		for _, arg := range directive.Arguments {
+			switch arg.Name.Value {
+			case "regex", "value":
+				// The string is used as is since the pattern may contain double underscores
+				value, ok := arg.Value.(*ast.StringValue)
+				if !ok {
+					return fmt.Errorf("invalid value provided for %s in %s - wanted a string", arg.Name.Value, directivePattern)
+				}
+				regex, err := regexp.Compile(value.Value)
+				if err != nil {
+					return fmt.Errorf("invalid pattern provided in %s - %v", directivePattern, err)
+				}
+				v.Pattern, v.regex = value.Value, regex
+			}
+		}
+		if v.Pattern == "" {
+			return fmt.Errorf("%s directive must be accompanied with regex field", directivePattern)
+		}
+
+	case directiveEmail:
+		v.IsEmail = true
+	}
+	return nil
+}
+
+// checkValidatorKind ensures the validators of a field can be applied to its type
+func checkValidatorKind(field *SchemaFieldType) error {
+	v := field.Validators
+	if v == nil {
+		return nil
+	}
+
+	isString := field.Kind == typeString || field.Kind == TypeID
+	if (v.MinLength != nil || v.MaxLength != nil) && !isString {
+		return fmt.Errorf("invalid type for field %s - %s directive can only be used on strings", field.FieldName, directiveLength)
+	}
+	if (v.Pattern != "" || v.IsEmail) && !isString {
+		return fmt.Errorf("invalid type for field %s - %s and %s directives can only be used on strings", field.FieldName, directivePattern, directiveEmail)
+	}
+	if (v.Min != nil || v.Max != nil) && field.Kind != typeInteger && field.Kind != typeFloat {
+		return fmt.Errorf("invalid type for field %s - %s directive can only be used on numbers", field.FieldName, directiveRange)
+	}
+	if v.MinLength != nil && v.MaxLength != nil && *v.MinLength > *v.MaxLength {
+		return fmt.Errorf("invalid %s for field %s - min cannot be greater than max", directiveLength, field.FieldName)
+	}
+	if v.Min != nil && v.Max != nil && *v.Min > *v.Max {
+		return fmt.Errorf("invalid %s for field %s - min cannot be greater than max", directiveRange, field.FieldName)
+	}
+	return nil
+}
+
+// checkValidators checks the value of a field (after its type has been checked) against the validators of the field.
+// Each value of a list is checked on its own
+func checkValidators(col string, value interface{}, field *SchemaFieldType) error {
+	v := field.Validators
+	if v == nil {
+		return nil
+	}
+
+	switch val := value.(type) {
+	case []interface{}:
+		for _, item := range val {
+			if err := checkValidators(col, item, field); err != nil {
+				return err
+			}
+		}
+
+	case string:
+		length := utf8.RuneCountInString(val)
+		if v.MinLength != nil && length < *v.MinLength {
+			return fmt.Errorf("invalid value received for field %s in collection %s - length must be at least %d", field.FieldName, col, *v.MinLength)
+		}
+		if v.MaxLength != nil && length > *v.MaxLength {
+			return fmt.Errorf("invalid value received for field %s in collection %s - length must be at most %d", field.FieldName, col, *v.MaxLength)
+		}
+		if v.regex != nil && !v.regex.MatchString(val) {
+			return fmt.Errorf("invalid value received for field %s in collection %s - must match the pattern %s", field.FieldName, col, v.Pattern)
+		}
+		if v.IsEmail && !emailRegex.MatchString(val) {
+			return fmt.Errorf("invalid value received for field %s in collection %s - must be a valid email", field.FieldName, col)
+		}
+
+	case int, int32, int64, float32, float64:
+		number, _ := strconv.ParseFloat(fmt.Sprintf("%v", val), 64)
+		if v.Min != nil && number < *v.Min {
+			return fmt.Errorf("invalid value received for field %s in collection %s - must be at least %s", field.FieldName, col, formatNumber(*v.Min))
+		}
+		if v.Max != nil && number > *v.Max {
+			return fmt.Errorf("invalid value received for field %s in collection %s - must be at most %s", field.FieldName, col, formatNumber(*v.Max))
+		}
+	}
+	return nil
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// validatorConditions returns the conditions of the check constraint enforcing the validators of a field. MySQL is
+// skipped since its versions prior to 8.0.16 ignore check constraints. SQLite and SQL Server don't support regular
+// expressions, so the pattern and email validators are enforced only by space cloud for them
+func validatorConditions(dbType string, field *SchemaFieldType) []string {
+	v := field.Validators
+	if v == nil || field.IsList {
+		return nil
+	}
+
+	var lengthFunction string
+	switch utils.DBType(dbType) {
+	case utils.Postgres, utils.SQLite:
+		lengthFunction = "length"
+	case utils.SqlServer:
+		lengthFunction = "LEN"
+	default:
+		return nil
+	}
+
+	var conditions []string
+	if v.MinLength != nil {
+		conditions = append(conditions, lengthFunction+"("+field.FieldName+") >= "+strconv.Itoa(*v.MinLength))
+	}
+	if v.MaxLength != nil {
+		conditions = append(conditions, lengthFunction+"("+field.FieldName+") <= "+strconv.Itoa(*v.MaxLength))
+	}
+	if v.Min != nil {
+		conditions = append(conditions, field.FieldName+" >= "+formatNumber(*v.Min))
+	}
+	if v.Max != nil {
+		conditions = append(conditions, field.FieldName+" <= "+formatNumber(*v.Max))
+	}
+	if utils.DBType(dbType) == utils.Postgres {
+		if v.Pattern != "" {
+			conditions = append(conditions, field.FieldName+" ~ '"+strings.Replace(v.Pattern, "'", "''", -1)+"'")
+		}
+		if v.IsEmail {
+			conditions = append(conditions, field.FieldName+" ~ '"+emailPattern+"'")
+		}
+	}
+	return conditions
+}
+
+var checkBoundRegex = regexp.MustCompile(`(>=|<=)\s*[(']*\s*(-?\d+(?:\.\d+)?)`)
+
+// isValidatorCheck reports whether the check constraint of a string column enforces validators rather than the
+// values of an enum. Only the checks of validators compare lengths or match patterns
+func isValidatorCheck(check string) bool {
+	unquoted := enumValueRegex.ReplaceAllString(check, "")
+	return strings.Contains(unquoted, "~") || checkBoundRegex.MatchString(unquoted)
+}
+
+// inspectionValidators loads the validators of a field from the check constraint of its column
+func inspectionValidators(check string, fieldDetails *SchemaFieldType) {
+	v := &ValidatorProperties{}
+	isString := fieldDetails.Kind == typeString || fieldDetails.Kind == TypeID
+
+	bounds := check
+	if isString {
+		// The patterns may contain comparisons of their own
+		bounds = enumValueRegex.ReplaceAllString(check, "")
+		if strings.Contains(bounds, "~") {
+			for _, pattern := range inspectionEnumValues(check) {
+				if pattern == emailPattern {
+					v.IsEmail = true
+				} else {
+					v.Pattern = pattern
+				}
+			}
+		}
+	}
+
+	for _, match := range checkBoundRegex.FindAllStringSubmatch(bounds, -1) {
+		number, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case isString && match[1] == ">=":
+			length := int(number)
+			v.MinLength = &length
+		case isString:
+			length := int(number)
+			v.MaxLength = &length
+		case match[1] == ">=":
+			v.Min = &number
+		default:
+			v.Max = &number
+		}
+	}
+
+	if !reflect.DeepEqual(v, &ValidatorProperties{}) {
+		fieldDetails.Validators = v
+	}
+}
+
+// validatorDirectives returns the directives describing the validators of a field in the schema
+func validatorDirectives(v *ValidatorProperties) string {
+	var directives []string
+	if v.MinLength != nil || v.MaxLength != nil {
+		var args []string
+		if v.MinLength != nil {
+			args = append(args, "min: "+strconv.Itoa(*v.MinLength))
+		}
+		if v.MaxLength != nil {
+			args = append(args, "max: "+strconv.Itoa(*v.MaxLength))
+		}
+		directives = append(directives, "@"+directiveLength+"("+strings.Join(args, ", ")+")")
+	}
+	if v.Min != nil || v.Max != nil {
+		var args []string
+		if v.Min != nil {
+			args = append(args, "min: "+formatNumber(*v.Min))
+		}
+		if v.Max != nil {
+			args = append(args, "max: "+formatNumber(*v.Max))
+		}
+		directives = append(directives, "@"+directiveRange+"("+strings.Join(args, ", ")+")")
+	}
+	if v.Pattern != "" {
+		pattern, _ := json.Marshal(v.Pattern)
+		directives = append(directives, "@"+directivePattern+"(regex: "+string(pattern)+")")
+	}
+	if v.IsEmail {
+		directives = append(directives, "@"+directiveEmail)
+	}
+	return strings.Join(directives, " ")
+}
@@ -0,0 +1,217 @@
+package schema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+const validatorsSchema = `type users {
+	id: ID! @primary
+	name: String @length(min: 2, max: 5)
+	code: String @pattern(regex: "^[a-z]+$")
+	email: String @email
+	age: Integer @range(min: 0, max: 150)
+	score: Float @range(max: 9.5)
+	tags: [String] @length(max: 3)
+}`
+
+func TestSchema_ValidateValidators(t *testing.T) {
+	var tests = []struct {
+		name    string
+		doc     map[string]interface{}
+		wantErr string
+	}{
+		{name: "valid document", doc: map[string]interface{}{"name": "ab", "code": "abc", "email": "a@b.io", "age": 10, "score": 9.5, "tags": []interface{}{"a"}}},
+		{name: "string too short", doc: map[string]interface{}{"name": "a"}, wantErr: "field name in collection users - length must be at least 2"},
+		{name: "string too long", doc: map[string]interface{}{"name": "abcdef"}, wantErr: "field name in collection users - length must be at most 5"},
+		{name: "length counts characters", doc: map[string]interface{}{"name": "ééééé"}},
+		{name: "pattern mismatch", doc: map[string]interface{}{"code": "ABC"}, wantErr: "field code in collection users - must match the pattern ^[a-z]+$"},
+		{name: "invalid email", doc: map[string]interface{}{"email": "a@b"}, wantErr: "field email in collection users - must be a valid email"},
+		{name: "number too small", doc: map[string]interface{}{"age": -1}, wantErr: "field age in collection users - must be at least 0"},
+		{name: "number too large", doc: map[string]interface{}{"score": 9.6}, wantErr: "field score in collection users - must be at most 9.5"},
+		{name: "list values are checked", doc: map[string]interface{}{"tags": []interface{}{"a", "abcd"}}, wantErr: "field tags in collection users - length must be at most 3"},
+	}
+
+	s := Init(crud.Init(false), false)
+	schemaDoc, err := s.parser(config.Crud{"mongo": &config.CrudStub{Collections: map[string]*config.TableRule{"users": {Schema: validatorsSchema}}}})
+	if err != nil {
+		t.Fatal("could not parse schema:", err)
+	}
+	s.SchemaDoc = schemaDoc
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := map[string]interface{}{"id": "1"}
+			for k, v := range tt.doc {
+				doc[k] = v
+			}
+			err := s.ValidateCreateOperation("mongo", "users", &model.CreateRequest{Document: doc, Operation: utils.One})
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.HasSuffix(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateCreateOperation() error = %v; want %s", err, tt.wantErr)
+			}
+
+			update := map[string]interface{}{"$set": tt.doc}
+			err = s.ValidateUpdateOperation("mongo", "users", utils.All, update, map[string]interface{}{"id": "1"})
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.HasSuffix(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateUpdateOperation() error = %v; want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateMathValidators(t *testing.T) {
+	var tests = []struct {
+		name    string
+		update  map[string]interface{}
+		wantErr string
+	}{
+		{name: "min within range", update: map[string]interface{}{"$min": map[string]interface{}{"age": 5}}},
+		{name: "min out of range", update: map[string]interface{}{"$min": map[string]interface{}{"age": -5}}, wantErr: "field age in collection users - must be at least 0"},
+		{name: "max out of range", update: map[string]interface{}{"$max": map[string]interface{}{"score": 10.5}}, wantErr: "field score in collection users - must be at most 9.5"},
+		{name: "every field is checked", update: map[string]interface{}{"$max": map[string]interface{}{"age": 10, "score": 10.5}}, wantErr: "field score in collection users - must be at most 9.5"},
+		{name: "inc depends on the value stored", update: map[string]interface{}{"$inc": map[string]interface{}{"age": 200}}},
+	}
+
+	s := Init(crud.Init(false), false)
+	schemaDoc, err := s.parser(config.Crud{"mongo": &config.CrudStub{Collections: map[string]*config.TableRule{"users": {Schema: validatorsSchema}}}})
+	if err != nil {
+		t.Fatal("could not parse schema:", err)
+	}
+	s.SchemaDoc = schemaDoc
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.ValidateUpdateOperation("mongo", "users", utils.All, tt.update, map[string]interface{}{"id": "1"})
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.HasSuffix(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateUpdateOperation() error = %v; want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchema_InvalidValidators(t *testing.T) {
+	var tests = []struct {
+		name, schema string
+	}{
+		{name: "length on a number", schema: `type users { id: ID! @primary age: Integer @length(max: 2) }`},
+		{name: "range on a string", schema: `type users { id: ID! @primary name: String @range(min: 1) }`},
+		{name: "email on a boolean", schema: `type users { id: ID! @primary ok: Boolean @email }`},
+		{name: "length without arguments", schema: `type users { id: ID! @primary name: String @length }`},
+		{name: "min greater than max", schema: `type users { id: ID! @primary name: String @length(min: 3, max: 2) }`},
+		{name: "invalid pattern", schema: `type users { id: ID! @primary name: String @pattern(regex: "[a-") }`},
+	}
+
+	s := Init(crud.Init(false), false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.parser(config.Crud{"mongo": &config.CrudStub{Collections: map[string]*config.TableRule{"users": {Schema: tt.schema}}}}); err == nil {
+				t.Error("parser() succeeded for invalid validators")
+			}
+		})
+	}
+}
+
+func TestInspectionValidators(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	floatPtr := func(f float64) *float64 { return &f }
+
+	var tests = []struct {
+		name, kind string
+		check      string // the check constraint as returned by the database
+		want       *ValidatorProperties
+	}{
+		{name: "postgres length and pattern", kind: typeString, check: "CHECK (((length((name)::text) >= 2) AND (length((name)::text) <= 5) AND ((name)::text ~ '^[a-z]+$'::text)))", want: &ValidatorProperties{MinLength: intPtr(2), MaxLength: intPtr(5), Pattern: "^[a-z]+$"}},
+		{name: "postgres email", kind: typeString, check: "CHECK (((name)::text ~ '" + emailPattern + "'::text))", want: &ValidatorProperties{IsEmail: true}},
+		{name: "postgres range", kind: typeFloat, check: "CHECK (((name >= (-1.5)::double precision) AND (name <= (10)::double precision)))", want: &ValidatorProperties{Min: floatPtr(-1.5), Max: floatPtr(10)}},
+		{name: "sqlserver length", kind: typeString, check: "(len([name])>=(2) AND len([name])<=(5))", want: &ValidatorProperties{MinLength: intPtr(2), MaxLength: intPtr(5)}},
+		{name: "sqlserver range", kind: typeInteger, check: "([name]>=(0) AND [name]<=(150))", want: &ValidatorProperties{Min: floatPtr(0), Max: floatPtr(150)}},
+		{name: "sqlite range", kind: typeInteger, check: "name >= 0", want: &ValidatorProperties{Min: floatPtr(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &SchemaFieldType{FieldName: "name", Kind: tt.kind}
+			inspectionCheckConstraint("users", tt.check, field)
+			if field.Kind != tt.kind {
+				t.Errorf("inspectionCheckConstraint() kind = %s; want %s", field.Kind, tt.kind)
+			}
+			if !reflect.DeepEqual(field.Validators, tt.want) {
+				t.Errorf("inspectionCheckConstraint() validators = %s; want %s", validatorDirectives(field.Validators), validatorDirectives(tt.want))
+			}
+		})
+	}
+}
+
+func TestSchema_Validators(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-validators")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tables := map[string]*config.TableRule{"users": {Schema: validatorsSchema}}
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: tables}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the table:", err)
+	}
+	queries := strings.Join(plan["users"], "\n")
+	for _, want := range []string{
+		"CONSTRAINT check__users__name CHECK (length(name) >= 2 AND length(name) <= 5)",
+		"CONSTRAINT check__users__age CHECK (age >= 0 AND age <= 150)",
+		"CONSTRAINT check__users__score CHECK (score <= 9.5)",
+	} {
+		if !strings.Contains(queries, want) {
+			t.Errorf("PlanSchemaModifyAll() = %v; want it to contain %s", queries, want)
+		}
+	}
+
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not create the table:", err)
+	}
+
+	// The validators are inspected from the check constraints
+	plan, err = s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the table again:", err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("PlanSchemaModifyAll() = %v; want no queries for the created table", plan)
+	}
+	sdl, err := s.SchemaInspection(ctx, "sqlite", "project", "users")
+	if err != nil {
+		t.Fatal("could not inspect the table:", err)
+	}
+	for _, want := range []string{"@length(min: 2, max: 5)", "@range(min: 0, max: 150)", "@range(max: 9.5)"} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SchemaInspection() = %s; want it to contain %s", sdl, want)
+		}
+	}
+
+	// The database rejects the values bypassing the validation of the schema
+	req := &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"id": "1", "age": 200}}
+	if err := c.InternalCreate(ctx, "sqlite", "project", "users", req); err == nil {
+		t.Error("InternalCreate() succeeded for a value violating the check constraint")
+	}
+}
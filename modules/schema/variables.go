@@ -1,5 +1,7 @@
 package schema
 
+import "regexp"
+
 type (
 
 	// schemaType is the data structure for storing the parsed values of schema string
@@ -26,10 +28,37 @@ type (
 		IsLinked    bool
 		IsForeign   bool
 		IsDefault   bool
+		IsVersion   bool
 		IndexInfo   *TableProperties
 		LinkedTable *TableProperties
 		JointTable  *TableProperties
 		Default     interface{}
+
+		// PrimaryKeyInfo holds the position of the field in a primary key spanning multiple fields
+		PrimaryKeyInfo *TableProperties
+
+		// EnumInfo holds the name and the values of the enum type of the field
+		EnumInfo *EnumProperties
+
+		// Validators holds the constraints on the values of the field
+		Validators *ValidatorProperties
+	}
+
+	// ValidatorProperties describes the constraints on the values of a field. The lengths apply to strings while
+	// the range applies to numbers
+	ValidatorProperties struct {
+		MinLength, MaxLength *int
+		Min, Max             *float64
+		Pattern              string
+		IsEmail              bool
+
+		regex *regexp.Regexp
+	}
+
+	// EnumProperties describes an enum type defined in the schema
+	EnumProperties struct {
+		Name   string
+		Values []string
 	}
 
 	TableProperties struct {
@@ -38,6 +67,9 @@ type (
 		DBType       string
 		Group, Sort  string
 		Order        int
+
+		// ConstraintName is the name of the primary or foreign key constraint found during inspection
+		ConstraintName string
 	}
 )
 
@@ -51,6 +83,7 @@ const (
 	sqlTypeIDSize      string = "50"
 	typeObject         string = "Object"
 	typeEnum           string = "Enum"
+	typeJSON           string = "JSON"
 	directiveUnique    string = "unique"
 	directiveIndex     string = "index"
 	directiveForeign   string = "foreign"
@@ -59,6 +92,11 @@ const (
 	directiveUpdatedAt string = "updatedAt"
 	directiveLink      string = "link"
 	directiveDefault   string = "default"
+	directiveVersion   string = "version"
+	directiveLength    string = "length"
+	directiveRange     string = "range"
+	directivePattern   string = "pattern"
+	directiveEmail     string = "email"
 
 	defaultIndexName  string = ""
 	defaultIndexSort  string = "asc"
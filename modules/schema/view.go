@@ -0,0 +1,183 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+// planViewCreation returns the queries which create or replace the view backing a collection. No queries are
+// returned if the view already exists with the same definition. The query returned for mongo is only descriptive
+// since mongo views are created with a command
+func (s *Schema) planViewCreation(ctx context.Context, dbAlias, project, col string, view *config.View) ([]string, error) {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	current := s.currentView(dbAlias, col)
+	if reflect.DeepEqual(current, view) {
+		exists, err := s.collectionExists(ctx, dbAlias, project, col)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, nil
+		}
+	}
+
+	if dbType == string(utils.Mongo) {
+		if view.Source == "" || view.Materialized {
+			return nil, fmt.Errorf("invalid view for collection (%s) - mongo views need a source and cannot be materialized", col)
+		}
+		pipeline := view.Pipeline
+		if pipeline == nil {
+			pipeline = []interface{}{}
+		}
+		data, err := json.Marshal(pipeline)
+		if err != nil {
+			return nil, err
+		}
+		return []string{fmt.Sprintf("db.createView(%q, %q, %s)", col, view.Source, string(data))}, nil
+	}
+
+	// SQLite does not support schemas. Hence views are never scoped by project
+	removeProjectScope := s.removeProjectScope || dbType == string(utils.SQLite)
+	return viewQueries(dbType, getTableName(project, col, removeProjectScope), view, current)
+}
+
+// viewQueries returns the queries which create or replace a sql view. The view currently backing the table is
+// needed on postgres since a view cannot be replaced by a materialized view (and vice versa)
+func viewQueries(dbType, table string, view, current *config.View) ([]string, error) {
+	if view.Query == "" {
+		return nil, fmt.Errorf("invalid view for table (%s) - query is required for sql views", table)
+	}
+	if view.Materialized && utils.DBType(dbType) != utils.Postgres {
+		return nil, fmt.Errorf("invalid view for table (%s) - materialized views are only supported on postgres", table)
+	}
+
+	switch utils.DBType(dbType) {
+	case utils.Postgres:
+		// Postgres cannot replace a view whose columns have changed, so the view is always recreated
+		isMaterialized := view.Materialized
+		if current != nil {
+			isMaterialized = current.Materialized
+		}
+		drop := "DROP VIEW IF EXISTS " + table
+		if isMaterialized {
+			drop = "DROP MATERIALIZED VIEW IF EXISTS " + table
+		}
+		create := "CREATE VIEW " + table + " AS " + view.Query
+		if view.Materialized {
+			create = "CREATE MATERIALIZED VIEW " + table + " AS " + view.Query
+		}
+		return []string{drop, create}, nil
+	case utils.MySQL:
+		return []string{"CREATE OR REPLACE VIEW " + table + " AS " + view.Query}, nil
+	case utils.SqlServer:
+		return []string{"CREATE OR ALTER VIEW " + table + " AS " + view.Query}, nil
+	case utils.SQLite:
+		return []string{"DROP VIEW IF EXISTS " + table, "CREATE VIEW " + table + " AS " + view.Query}, nil
+	}
+	return nil, utils.ErrUnsupportedDatabase
+}
+
+// createView creates or replaces the view backing a collection. The queries applied to sql databases are recorded as
+// a migration
+func (s *Schema) createView(ctx context.Context, dbAlias, project, col, appliedBy string, view *config.View, queries []string) error {
+	dbType, err := s.crud.GetDBType(dbAlias)
+	if err != nil {
+		return err
+	}
+
+	if dbType == string(utils.Mongo) {
+		return s.crud.SetView(ctx, dbAlias, project, col, view.Source, view.Pipeline)
+	}
+
+	return s.applyMigration(ctx, dbAlias, project, col, appliedBy, queries)
+}
+
+// currentView returns the view backing the collection as per the config. It must be called with the lock held
+func (s *Schema) currentView(dbAlias, col string) *config.View {
+	dbStub, p := s.config[dbAlias]
+	if !p || dbStub == nil {
+		return nil
+	}
+	rule, p := dbStub.Collections[col]
+	if !p || rule == nil {
+		return nil
+	}
+	return rule.View
+}
+
+func (s *Schema) collectionExists(ctx context.Context, dbAlias, project, col string) (bool, error) {
+	collections, err := s.crud.GetCollections(ctx, project, dbAlias)
+	if err != nil {
+		return false, err
+	}
+	for _, collection := range collections {
+		if strings.EqualFold(collection.TableName, col) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RefreshView refreshes the data of a materialized view
+func (s *Schema) RefreshView(ctx context.Context, dbAlias, project, col string) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	view := s.currentView(dbAlias, col)
+	if view == nil || !view.Materialized {
+		return fmt.Errorf("collection (%s) is not a materialized view", col)
+	}
+
+	return s.crud.RawBatch(ctx, dbAlias, []string{"REFRESH MATERIALIZED VIEW " + getTableName(project, col, s.removeProjectScope)})
+}
+
+// scheduleViewRefreshes refreshes the materialized views of the config at their refresh intervals. The refreshes
+// scheduled for the previous config are stopped. It must be called with the lock held
+func (s *Schema) scheduleViewRefreshes(conf config.Crud, project string) {
+	if s.stopRefreshes != nil {
+		s.stopRefreshes()
+		s.stopRefreshes = nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for dbAlias, dbStub := range conf {
+		if dbStub == nil || !dbStub.Enabled {
+			continue
+		}
+		for col, rule := range dbStub.Collections {
+			if rule == nil || rule.View == nil || !rule.View.Materialized || rule.View.RefreshInterval <= 0 {
+				continue
+			}
+			go s.refreshView(ctx, dbAlias, project, col, time.Duration(rule.View.RefreshInterval)*time.Second)
+		}
+	}
+	s.stopRefreshes = cancel
+}
+
+func (s *Schema) refreshView(ctx context.Context, dbAlias, project, col string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RefreshView(ctx, dbAlias, project, col); err != nil && ctx.Err() == nil {
+				log.Println("Schema module could not refresh the materialized view", col, "-", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/utils"
+)
+
+func Test_viewQueries(t *testing.T) {
+	var tests = []struct {
+		name          string
+		dbType        string
+		view, current *config.View
+		want          []string
+		wantErr       bool
+	}{
+		{name: "postgres view", dbType: "postgres", view: &config.View{Query: "SELECT * FROM test.todos"}, want: []string{"DROP VIEW IF EXISTS test.done", "CREATE VIEW test.done AS SELECT * FROM test.todos"}},
+		{name: "postgres materialized view", dbType: "postgres", view: &config.View{Query: "SELECT * FROM test.todos", Materialized: true}, want: []string{"DROP MATERIALIZED VIEW IF EXISTS test.done", "CREATE MATERIALIZED VIEW test.done AS SELECT * FROM test.todos"}},
+		{name: "postgres view replacing a materialized view", dbType: "postgres", view: &config.View{Query: "SELECT * FROM test.todos"}, current: &config.View{Query: "SELECT * FROM test.todos", Materialized: true}, want: []string{"DROP MATERIALIZED VIEW IF EXISTS test.done", "CREATE VIEW test.done AS SELECT * FROM test.todos"}},
+		{name: "mysql view", dbType: "mysql", view: &config.View{Query: "SELECT * FROM test.todos"}, want: []string{"CREATE OR REPLACE VIEW test.done AS SELECT * FROM test.todos"}},
+		{name: "sqlserver view", dbType: "sqlserver", view: &config.View{Query: "SELECT * FROM test.todos"}, want: []string{"CREATE OR ALTER VIEW test.done AS SELECT * FROM test.todos"}},
+		{name: "materialized view on mysql", dbType: "mysql", view: &config.View{Query: "SELECT * FROM test.todos", Materialized: true}, wantErr: true},
+		{name: "view without a query", dbType: "postgres", view: &config.View{Source: "todos"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := viewQueries(tt.dbType, "test.done", tt.view, tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("viewQueries() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("viewQueries() = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_Views(t *testing.T) {
+	dir, err := ioutil.TempDir("", "space-cloud-views")
+	if err != nil {
+		t.Fatal("could not create temp dir:", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tables := map[string]*config.TableRule{
+		"todos": {Schema: "type todos { id: ID! @primary text: String done: Boolean }"},
+		"done":  {View: &config.View{Query: "SELECT id, text FROM todos WHERE done = 1"}},
+	}
+	dbConfig := config.Crud{"sqlite": &config.CrudStub{Enabled: true, Conn: filepath.Join(dir, "test.db"), Collections: tables}}
+
+	c := crud.Init(false)
+	c.SetHooks(&model.CrudHooks{}, func(project, dbType, col string, count int64, op utils.OperationType) {})
+	if err := c.SetConfig("project", dbConfig); err != nil {
+		t.Fatal("could not set crud config:", err)
+	}
+	s := Init(c, false)
+	if err := s.SetConfig(dbConfig, "project"); err != nil {
+		t.Fatal("could not set schema config:", err)
+	}
+
+	ctx := context.Background()
+	plan, err := s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the view:", err)
+	}
+	if want := []string{"DROP VIEW IF EXISTS done", "CREATE VIEW done AS SELECT id, text FROM todos WHERE done = 1"}; !reflect.DeepEqual(plan["done"], want) {
+		t.Errorf("PlanSchemaModifyAll() = %v; want %v", plan["done"], want)
+	}
+
+	// The view is created after the table it selects from
+	if err := s.SchemaModifyAll(ctx, "sqlite", "project", "admin", tables); err != nil {
+		t.Fatal("could not create the view:", err)
+	}
+	plan, err = s.PlanSchemaModifyAll(ctx, "sqlite", "project", tables)
+	if err != nil {
+		t.Fatal("could not plan the view again:", err)
+	}
+	if len(plan) != 0 {
+		t.Errorf("PlanSchemaModifyAll() = %v; want no queries for the created view", plan)
+	}
+
+	for _, doc := range []map[string]interface{}{{"id": "1", "text": "a", "done": true}, {"id": "2", "text": "b", "done": false}} {
+		if err := c.InternalCreate(ctx, "sqlite", "project", "todos", &model.CreateRequest{Operation: utils.One, Document: doc}); err != nil {
+			t.Fatal("could not create the todo:", err)
+		}
+	}
+	result, err := c.InternalRead(ctx, "sqlite", "project", "done", &model.ReadRequest{Operation: utils.All, Find: map[string]interface{}{}})
+	if err != nil {
+		t.Fatal("could not read the view:", err)
+	}
+	if docs, ok := result.([]interface{}); !ok || len(docs) != 1 {
+		t.Errorf("InternalRead() = %v; want the done todo", result)
+	}
+
+	// Views are read only
+	req := &model.CreateRequest{Operation: utils.One, Document: map[string]interface{}{"id": "3", "text": "c"}}
+	if err := c.Create(ctx, "sqlite", "project", "done", req); err == nil {
+		t.Error("Create() succeeded on a view")
+	}
+
+	if err := s.RefreshView(ctx, "sqlite", "project", "done"); err == nil {
+		t.Error("RefreshView() succeeded for a view which isn't materialized")
+	}
+}
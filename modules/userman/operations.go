@@ -43,7 +43,7 @@ func (m *Module) Profile(ctx context.Context, token, dbType, project, id string)
 	}
 
 	// Perform database read operation
-	res, err := m.crud.Read(ctx, dbType, project, "users", req)
+	res, err := m.crud.InternalRead(ctx, dbType, project, "users", req)
 	if err != nil {
 		return http.StatusInternalServerError, nil, err
 	}
@@ -73,7 +73,7 @@ func (m *Module) Profiles(ctx context.Context, token, dbType, project string) (i
 		return status, nil, err
 	}
 
-	res, err := m.crud.Read(ctx, dbType, project, "users", req)
+	res, err := m.crud.InternalRead(ctx, dbType, project, "users", req)
 	if err != nil {
 		return http.StatusInternalServerError, nil, err
 	}
@@ -98,10 +98,10 @@ func (m *Module) EmailSignIn(ctx context.Context, dbType, project, email, passwo
 		return http.StatusNotFound, nil, errors.New("Email sign in feature is not enabled")
 	}
 
-	// Create read request
+	// Create read request. Users are always read from the primary so that a user can sign in right after signing up
 	readReq := &model.ReadRequest{Find: map[string]interface{}{"email": email}, Operation: utils.One}
 
-	user, err := m.crud.Read(ctx, dbType, project, "users", readReq)
+	user, err := m.crud.InternalRead(ctx, dbType, project, "users", readReq)
 	if err != nil {
 		return http.StatusNotFound, nil, errors.New("User not found")
 	}
@@ -153,9 +153,9 @@ func (m *Module) EmailSignUp(ctx context.Context, dbType, project, email, name,
 		return http.StatusInternalServerError, nil, errors.New("Failed to hash password")
 	}
 
-	// Create read request
+	// Create read request. The primary is checked since a replica may not have the user yet
 	readReq := &model.ReadRequest{Find: map[string]interface{}{"email": email}, Operation: utils.One}
-	_, err = m.crud.Read(ctx, dbType, project, "users", readReq)
+	_, err = m.crud.InternalRead(ctx, dbType, project, "users", readReq)
 	if err == nil {
 		return http.StatusConflict, nil, errors.New("User with provided email already exists")
 	}
@@ -252,7 +252,7 @@ func (m *Module) EmailEditProfile(ctx context.Context, token, dbType, project, i
 	}
 
 	readReq := &model.ReadRequest{Find: map[string]interface{}{idString: id}, Operation: utils.One}
-	user, err1 := m.crud.Read(ctx, dbType, project, "users", readReq)
+	user, err1 := m.crud.InternalRead(ctx, dbType, project, "users", readReq)
 	if err1 != nil {
 		return http.StatusNotFound, nil, errors.New("User not found")
 	}
@@ -29,6 +29,20 @@ func (m *Manager) IsTokenValid(token string) error {
 	return err
 }
 
+// GetUserID returns the id of the admin the token was issued to. An empty id is returned if the token is invalid
+func (m *Manager) GetUserID(token string) string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	claims, err := m.parseToken(token)
+	if err != nil {
+		return ""
+	}
+
+	id, _ := claims["id"].(string)
+	return id
+}
+
 // ValidateSyncOperation validates if an operation is permitted based on the mode
 func (m *Manager) ValidateSyncOperation(c *config.Config, project *config.Project) bool {
 	m.lock.RLock()
@@ -24,6 +24,12 @@ const (
 	Upsert string = "upsert"
 )
 
+// SoftDeleteField is the field which holds the time at which a document of a soft delete collection was deleted
+const SoftDeleteField = "deleted_at"
+
+// TableMigrations is the table of a sql database in which the schema migrations applied to its tables are recorded
+const TableMigrations = "space_cloud_migrations"
+
 // DBType is the type of database used for a particular crud operation
 type DBType string
 
@@ -39,6 +45,23 @@ const (
 
 	// SqlServer is the type used for MsSQL
 	SqlServer DBType = "sqlserver"
+
+	// SQLite is the type used for the embedded SQLite database
+	SQLite DBType = "sqlite"
+)
+
+// BatchMode decides how batch requests are performed on databases which may not support transactions
+type BatchMode string
+
+const (
+	// BatchModeAuto uses a transaction when the deployment supports it and falls back to a sequential batch otherwise
+	BatchModeAuto BatchMode = "auto"
+
+	// BatchModeTransaction always uses a transaction. Batches fail if the deployment does not support it
+	BatchModeTransaction BatchMode = "transaction"
+
+	// BatchModeSequential performs the requests one after the other without a transaction
+	BatchModeSequential BatchMode = "sequential"
 )
 
 // Broker is the type of broker used by Space Cloud
@@ -186,15 +209,25 @@ type FieldType struct {
 	FieldKey     string `db:"Key"`
 	FieldDefault string `db:"Default"`
 	FieldExtra   string `db:"Extra"`
+
+	// FieldKeyOrder is the position of the column in the primary key
+	FieldKeyOrder int `db:"KeyOrder"`
+
+	// FieldKeyConstraint is the name of the primary key constraint the column is a part of
+	FieldKeyConstraint string `db:"KeyConstraint"`
+
+	// FieldCheck is the expression of the check constraint of the column
+	FieldCheck string `db:"Check"`
 }
 
 // ForeignKeysType is the type for storing  foreignkeys information of sql inspection
 type ForeignKeysType struct {
-	TableName      string `db:"TABLE_NAME"`
-	ColumnName     string `db:"COLUMN_NAME"`
-	ConstraintName string `db:"CONSTRAINT_NAME"`
-	RefTableName   string `db:"REFERENCED_TABLE_NAME"`
-	RefColumnName  string `db:"REFERENCED_COLUMN_NAME"`
+	TableName       string `db:"TABLE_NAME"`
+	ColumnName      string `db:"COLUMN_NAME"`
+	ConstraintName  string `db:"CONSTRAINT_NAME"`
+	RefTableName    string `db:"REFERENCED_TABLE_NAME"`
+	RefColumnName   string `db:"REFERENCED_COLUMN_NAME"`
+	OrdinalPosition int    `db:"ORDINAL_POSITION"` // The position of the column in a foreign key spanning multiple columns
 }
 
 //IndexType is the type use to indexkey information of sql inspection
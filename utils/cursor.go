@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrCursorWithoutSort is thrown when a cursor is used in a read request which has no sort keys
+var ErrCursorWithoutSort = errors.New("Cursor based pagination requires the sort option")
+
+// ErrInvalidCursor is thrown when the cursor provided cannot be decoded or does not match the sort keys
+var ErrInvalidCursor = errors.New("Invalid cursor provided")
+
+// EncodeCursor generates an opaque cursor from the values of the sort keys of a document. Extended json
+// is used so that types like object ids and dates survive the round trip
+func EncodeCursor(sort []string, doc map[string]interface{}) (string, error) {
+	values := make(primitive.A, len(sort))
+	for i, key := range sort {
+		values[i] = doc[strings.TrimPrefix(key, "-")]
+	}
+
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: values}}, true, false)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor returns the values of the sort keys stored in the cursor
+func DecodeCursor(sort []string, cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var obj struct {
+		V []interface{} `bson:"v"`
+	}
+	if err := bson.UnmarshalExtJSON(data, true, &obj); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if len(obj.V) != len(sort) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, v := range obj.V {
+		// Dates are converted back to time so that sql drivers can use them as well
+		if t, ok := v.(primitive.DateTime); ok {
+			obj.V[i] = time.Unix(0, int64(t)*int64(time.Millisecond)).UTC()
+		}
+	}
+
+	return obj.V, nil
+}
+
+// GenerateCursorFilter generates the find clause which selects the documents after (or before) the cursor
+// for the provided sort keys. For sort keys (a, -b) the documents after the cursor (x, y) are the ones
+// matching `a > x OR (a = x AND b < y)`. Only one of after and before may be provided. Reading before a
+// cursor requires reading in the reverse order, hence the sort keys to be used in the query are returned
+// as well. The documents read in that case need to be reversed
+func GenerateCursorFilter(sort []string, after, before *string) (map[string]interface{}, []string, error) {
+	if after != nil && before != nil {
+		return nil, nil, errors.New("Only one of after and before cursor can be provided")
+	}
+	if len(sort) == 0 {
+		return nil, nil, ErrCursorWithoutSort
+	}
+
+	cursor := after
+	if before != nil {
+		cursor = before
+		sort = ReverseSort(sort)
+	}
+
+	values, err := DecodeCursor(sort, *cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	or := make([]interface{}, len(sort))
+	for i, key := range sort {
+		// Match the documents which are equal on all the preceding sort keys
+		clause := make(map[string]interface{}, i+1)
+		for j := 0; j < i; j++ {
+			clause[strings.TrimPrefix(sort[j], "-")] = values[j]
+		}
+
+		op := "$gt"
+		if strings.HasPrefix(key, "-") {
+			op = "$lt"
+		}
+		clause[strings.TrimPrefix(key, "-")] = map[string]interface{}{op: values[i]}
+		or[i] = clause
+	}
+
+	return map[string]interface{}{"$or": or}, sort, nil
+}
+
+// ReverseSort reverses the direction of each of the sort keys
+func ReverseSort(sort []string) []string {
+	reversed := make([]string, len(sort))
+	for i, key := range sort {
+		if strings.HasPrefix(key, "-") {
+			reversed[i] = strings.TrimPrefix(key, "-")
+		} else {
+			reversed[i] = "-" + key
+		}
+	}
+	return reversed
+}
+
+// ReverseDocs reverses the order of the documents in place
+func ReverseDocs(docs []interface{}) {
+	for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+		docs[i], docs[j] = docs[j], docs[i]
+	}
+}
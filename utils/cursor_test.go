@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	date := time.Date(2019, 10, 1, 10, 30, 0, 0, time.UTC)
+	doc := map[string]interface{}{"_id": id, "name": "todo", "count": int64(5), "createdAt": date}
+	sort := []string{"-createdAt", "name", "count", "_id"}
+
+	cursor, err := EncodeCursor(sort, doc)
+	if err != nil {
+		t.Fatal("EncodeCursor() error:", err)
+	}
+
+	values, err := DecodeCursor(sort, cursor)
+	if err != nil {
+		t.Fatal("DecodeCursor() error:", err)
+	}
+
+	want := []interface{}{date, "todo", int64(5), id}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("DecodeCursor() = %v; want %v", values, want)
+	}
+
+	if _, err := DecodeCursor([]string{"name"}, cursor); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursor() with different sort keys error = %v; want %v", err, ErrInvalidCursor)
+	}
+	if _, err := DecodeCursor(sort, "not a cursor"); err != ErrInvalidCursor {
+		t.Errorf("DecodeCursor() with garbage error = %v; want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestGenerateCursorFilter(t *testing.T) {
+	cursor, err := EncodeCursor([]string{"a", "-b"}, map[string]interface{}{"a": "x", "b": "y"})
+	if err != nil {
+		t.Fatal("EncodeCursor() error:", err)
+	}
+
+	var tests = []struct {
+		name          string
+		sort          []string
+		after, before *string
+		want          map[string]interface{}
+		wantSort      []string
+		wantErr       bool
+	}{
+		{
+			name:     "after cursor",
+			sort:     []string{"a", "-b"},
+			after:    &cursor,
+			want:     map[string]interface{}{"$or": []interface{}{map[string]interface{}{"a": map[string]interface{}{"$gt": "x"}}, map[string]interface{}{"a": "x", "b": map[string]interface{}{"$lt": "y"}}}},
+			wantSort: []string{"a", "-b"},
+		},
+		{
+			name:     "before cursor",
+			sort:     []string{"a", "-b"},
+			before:   &cursor,
+			want:     map[string]interface{}{"$or": []interface{}{map[string]interface{}{"a": map[string]interface{}{"$lt": "x"}}, map[string]interface{}{"a": "x", "b": map[string]interface{}{"$gt": "y"}}}},
+			wantSort: []string{"-a", "b"},
+		},
+		{name: "without sort", after: &cursor, wantErr: true},
+		{name: "both cursors", sort: []string{"a", "-b"}, after: &cursor, before: &cursor, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotSort, err := GenerateCursorFilter(tt.sort, tt.after, tt.before)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateCursorFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateCursorFilter() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotSort, tt.wantSort) {
+				t.Errorf("GenerateCursorFilter() sort = %v, want %v", gotSort, tt.wantSort)
+			}
+		})
+	}
+}
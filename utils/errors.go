@@ -1,6 +1,9 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrInvalidParams is thrown when the input parameters for an operation are invalid
 var ErrInvalidParams = errors.New("Invalid parameter provided")
@@ -16,3 +19,22 @@ var ErrDatabaseConnection = errors.New("Could not connect to database. Make sure
 
 // ErrDatabaseConfigAbsent is thrown when database config is not present
 var ErrDatabaseConfigAbsent = errors.New("No such database found in SC config file")
+
+// ErrVersionConflict is thrown when the document to be updated has been modified since the version expected by the update
+var ErrVersionConflict = errors.New("Document has been modified by another request. Read it again and retry")
+
+// BatchError is thrown when a request in a batch operation fails. A batch performed in a transaction gets rolled back
+// entirely. Otherwise (mongo in sequential mode, or in auto mode without transaction support) the requests before the
+// failing one stay applied
+type BatchError struct {
+	// Index is the position of the failing request in the batch
+	Index int
+	Err   error
+
+	// Counts holds the number of documents affected by each of the applied requests. It is empty if the batch got rolled back
+	Counts []int64
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("Request (%d) of batch failed: %v", e.Index, e.Err)
+}
@@ -87,13 +87,39 @@ func (holder *resultsHolder) fillErrorMessage(err error) {
 	holder.Unlock()
 }
 
+// loaderMap holds the data loaders of a graphql request. The page info of the sorted reads made at the top level of
+// the request is collected in it as well
 type loaderMap struct {
-	lock sync.Mutex
-	m    map[string]*dataloader.Loader
+	lock     sync.Mutex
+	m        map[string]*dataloader.Loader
+	pageInfo map[string]*model.PageInfo
 }
 
 func newLoaderMap() *loaderMap {
-	return &loaderMap{m: map[string]*dataloader.Loader{}}
+	return &loaderMap{m: map[string]*dataloader.Loader{}, pageInfo: map[string]*model.PageInfo{}}
+}
+
+func (l *loaderMap) setPageInfo(field string, pageInfo *model.PageInfo) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.pageInfo[field] = pageInfo
+}
+
+func (l *loaderMap) getPageInfo() map[string]*model.PageInfo {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(l.pageInfo) == 0 {
+		return nil
+	}
+	return l.pageInfo
+}
+
+// pagedResult holds the documents of a sorted read along with the cursors of the first and the last document
+type pagedResult struct {
+	docs     interface{}
+	pageInfo *model.PageInfo
 }
 
 func (l *loaderMap) get(key string, graph *Module) *dataloader.Loader {
@@ -145,7 +171,7 @@ func (graph *Module) dataLoaderBatchFn(c context.Context, keys dataloader.Keys)
 				defer wg.Done()
 
 				// Execute the query
-				res, err := graph.crud.Read(ctx, req.DBType, graph.project, req.Col, &req.Req)
+				res, pageInfo, err := graph.crud.Read(ctx, req.DBType, graph.project, req.Col, &req.Req)
 				if err != nil {
 
 					// Cancel the context and add the error response to the result
@@ -154,6 +180,12 @@ func (graph *Module) dataLoaderBatchFn(c context.Context, keys dataloader.Keys)
 					return
 				}
 
+				// Sorted reads carry their page info along
+				if pageInfo != nil {
+					holder.addResult(i, &dataloader.Result{Data: &pagedResult{docs: res, pageInfo: pageInfo}})
+					return
+				}
+
 				// Add the response to the result
 				holder.addResult(i, &dataloader.Result{Data: res})
 			}(index)
@@ -173,7 +205,7 @@ func (graph *Module) dataLoaderBatchFn(c context.Context, keys dataloader.Keys)
 	req := model.ReadRequest{Find: map[string]interface{}{"$or": holder.getWhereClauses()}, Operation: utils.All, Options: &model.ReadOptions{}}
 
 	// Fire the merged request
-	res, err := graph.crud.Read(ctx, dbType, graph.project, col, &req)
+	res, _, err := graph.crud.Read(ctx, dbType, graph.project, col, &req)
 	if err != nil {
 		holder.fillErrorMessage(err)
 	} else {
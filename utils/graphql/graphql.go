@@ -43,8 +43,14 @@ func (graph *Module) GetProjectID() string {
 	return graph.project
 }
 
-// ExecGraphQLQuery executes the provided graphql query
-func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb callback) {
+// WithRequestTimeout bounds the context of a graphql request by the request timeout of the crud module
+func (graph *Module) WithRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return graph.crud.WithRequestTimeout(ctx)
+}
+
+// ExecGraphQLQuery executes the provided graphql query. The page info of the sorted reads made at the top level of
+// the query is returned along with the result, keyed by the name of their field
+func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLRequest, token string, cb queryCallback) {
 
 	source := source.NewSource(&source.Source{
 		Body: []byte(req.Query),
@@ -53,13 +59,17 @@ func (graph *Module) ExecGraphQLQuery(ctx context.Context, req *model.GraphQLReq
 	// parse the source
 	doc, err := parser.Parse(parser.ParseParams{Source: source})
 	if err != nil {
-		cb(nil, err)
+		cb(nil, nil, err)
 		return
 	}
 
-	graph.execGraphQLDocument(ctx, doc, token, utils.M{"vars": req.Variables, "path": ""}, newLoaderMap(), nil, createCallback(cb))
+	loader := newLoaderMap()
+	graph.execGraphQLDocument(ctx, doc, token, utils.M{"vars": req.Variables, "path": ""}, loader, nil, createCallback(func(op interface{}, err error) {
+		cb(op, loader.getPageInfo(), err)
+	}))
 }
 
+type queryCallback func(op interface{}, pageInfo map[string]*model.PageInfo, err error)
 type callback func(op interface{}, err error)
 type dbCallback func(dbType, col string, op interface{}, err error)
 
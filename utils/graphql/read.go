@@ -63,6 +63,14 @@ func (graph *Module) execReadRequest(ctx context.Context, field *ast.Field, toke
 		// Create dataloader key
 		key := model.ReadRequestKey{DBType: dbType, Col: col, HasOptions: hasOptions, Req: *req}
 		result, err := dataLoader.Load(ctx, key)()
+		if paged, ok := result.(*pagedResult); ok {
+			result = paged.docs
+
+			// Only the page info of the reads at the top level of the request is returned
+			if _, p := store["coreParentKey"]; !p {
+				loader.setPageInfo(getFieldName(field), paged.pageInfo)
+			}
+		}
 		_ = graph.auth.PostProcessMethod(actions, result)
 		cb(dbType, col, result, err)
 	}()
@@ -189,6 +197,25 @@ func generateOptions(args []*ast.Argument, store utils.M) (*model.ReadOptions, b
 			}
 
 			options.Distinct = &tempString
+
+		case "after", "before":
+			hasOptions = true // Set the flag to true
+
+			temp, err := ParseValue(v.Value, store)
+			if err != nil {
+				return nil, hasOptions, err
+			}
+
+			tempString, ok := temp.(string)
+			if !ok {
+				return nil, hasOptions, fmt.Errorf("Invalid type for %s", v.Name.Value)
+			}
+
+			if v.Name.Value == "after" {
+				options.After = &tempString
+			} else {
+				options.Before = &tempString
+			}
 		}
 	}
 	return &options, hasOptions, nil
@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/spaceuptech/space-cloud/config"
+	"github.com/spaceuptech/space-cloud/model"
 	"github.com/spaceuptech/space-cloud/modules/crud"
 	"github.com/spaceuptech/space-cloud/modules/schema"
 	"github.com/spaceuptech/space-cloud/utils"
@@ -79,8 +80,13 @@ func HandleGetConnectionState(adminMan *admin.Manager, crud *crud.Module) http.H
 
 		connState := crud.GetConnectionState(ctx, dbType)
 
+		res := map[string]interface{}{"status": connState}
+		if poolStats, err := crud.GetPoolStats(dbType); err == nil {
+			res["pool"] = poolStats
+		}
+
 		w.WriteHeader(http.StatusOK) // http status code
-		json.NewEncoder(w).Encode(map[string]bool{"status": connState})
+		json.NewEncoder(w).Encode(res)
 		return
 	}
 }
@@ -126,6 +132,120 @@ func HandleDeleteCollection(adminMan *admin.Manager, crud *crud.Module, syncman
 	}
 }
 
+// HandleSoftDeletedDocs is an endpoint handler which purges or restores the soft deleted documents of a collection
+func HandleSoftDeletedDocs(adminMan *admin.Manager, crud *crud.Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		v := struct {
+			Find map[string]interface{} `json:"find"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&v)
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+		project := vars["project"]
+		col := vars["col"]
+
+		var count int64
+		var err error
+		switch vars["action"] {
+		case "purge":
+			count, err = crud.PurgeDeleted(r.Context(), dbType, project, col, v.Find)
+		case "restore":
+			count, err = crud.RestoreDeleted(r.Context(), dbType, project, col, v.Find)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid action (" + vars["action"] + ") provided"})
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK) // http status code
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": count})
+		return
+	}
+}
+
+// HandleExplainRead is an endpoint handler which returns the query generated for a read request along with the
+// plan the database would use to execute it
+func HandleExplainRead(adminMan *admin.Manager, crud *crud.Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		req := model.ReadRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+		project := vars["project"]
+		col := vars["col"]
+
+		// Create empty read options if it does not exist
+		if req.Options == nil {
+			req.Options = new(model.ReadOptions)
+		}
+
+		result, err := crud.Explain(r.Context(), dbType, project, col, &req)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK) // http status code
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+}
+
+// HandleGetSlowQueries is an endpoint handler which returns the recent operations on a database which took longer
+// than its slow query threshold
+func HandleGetSlowQueries(adminMan *admin.Manager, crud *crud.Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+
+		w.WriteHeader(http.StatusOK) // http status code
+		json.NewEncoder(w).Encode(map[string]interface{}{"queries": crud.GetSlowQueries(dbType)})
+		return
+	}
+}
+
 // HandleDatabaseConnection is an endpoint handler which updates database config & connects to database
 func HandleDatabaseConnection(adminMan *admin.Manager, crud *crud.Module, syncman *syncman.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -219,13 +339,28 @@ func HandleModifySchema(adminMan *admin.Manager, schemaArg *schema.Schema, syncm
 		// Create a context of execution
 		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 		defer cancel()
-		if err := schemaArg.SchemaModifyAll(ctx, dbType, project, map[string]*config.TableRule{col: &v}); err != nil {
+
+		// Only return the queries which would be run for a dry run
+		if r.URL.Query().Get("dryRun") == "true" {
+			plan, err := schemaArg.PlanSchemaModifyAll(ctx, dbType, project, map[string]*config.TableRule{col: &v})
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{"queries": plan[col]})
+			return
+		}
+
+		if err := schemaArg.SchemaModifyAll(ctx, dbType, project, adminMan.GetUserID(token), map[string]*config.TableRule{col: &v}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
 
-		if err := syncman.SetModifySchema(ctx, project, dbType, col, v.Schema); err != nil {
+		if err := syncman.SetModifySchema(ctx, project, dbType, col, v.Schema, v.View); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
@@ -237,6 +372,77 @@ func HandleModifySchema(adminMan *admin.Manager, schemaArg *schema.Schema, syncm
 	}
 }
 
+// HandleGetMigrations is an endpoint handler which returns the schema migrations applied to the tables of a database,
+// the most recent one first
+func HandleGetMigrations(adminMan *admin.Manager, schemaArg *schema.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+		project := vars["project"]
+
+		// Create a context of execution
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		migrations, err := schemaArg.GetMigrations(ctx, dbType, project, r.URL.Query().Get("col"))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK) // http status code
+		json.NewEncoder(w).Encode(map[string]interface{}{"migrations": migrations})
+		return
+	}
+}
+
+// HandleRefreshView is an endpoint handler which refreshes the data of a materialized view
+func HandleRefreshView(adminMan *admin.Manager, schemaArg *schema.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+		project := vars["project"]
+		col := vars["col"]
+
+		// Create a context of execution
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+
+		if err := schemaArg.RefreshView(ctx, dbType, project, col); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		w.WriteHeader(http.StatusOK) // http status code
+		json.NewEncoder(w).Encode(map[string]interface{}{})
+		return
+	}
+}
+
 // HandleCollectionRules is an endpoint handler which update database collection rules in config & creates collection if it doesn't exist
 func HandleCollectionRules(adminMan *admin.Manager, syncman *syncman.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -375,6 +581,15 @@ func HandleSchemaInspection(adminMan *admin.Manager, schemaArg *schema.Schema, s
 			return
 		}
 
+		// The inferred schema of mongo collections can be enforced by mongo itself
+		if r.URL.Query().Get("validator") == "true" {
+			if err := schemaArg.SetMongoValidator(ctx, dbType, project, col, schema); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+		}
+
 		w.WriteHeader(http.StatusOK) // http status codee
 		json.NewEncoder(w).Encode(map[string]interface{}{"schema": schema})
 		return
@@ -405,7 +620,21 @@ func HandleModifyAllSchema(adminMan *admin.Manager, schemaArg *schema.Schema, sy
 		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 		defer cancel()
 
-		if err := syncman.SetModifyAllSchema(ctx, dbType, project, schemaArg, v); err != nil {
+		// Only return the queries which would be run for each table for a dry run
+		if r.URL.Query().Get("dryRun") == "true" {
+			plan, err := schemaArg.PlanSchemaModifyAll(ctx, dbType, project, v.Collections)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{"queries": plan})
+			return
+		}
+
+		if err := syncman.SetModifyAllSchema(ctx, dbType, project, adminMan.GetUserID(token), schemaArg, v); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/spaceuptech/space-cloud/model"
+	"github.com/spaceuptech/space-cloud/modules/crud"
+	"github.com/spaceuptech/space-cloud/modules/schema"
+	"github.com/spaceuptech/space-cloud/utils"
+	"github.com/spaceuptech/space-cloud/utils/admin"
+)
+
+const (
+	// transferBatchSize is the number of documents read or inserted at a time while exporting or importing
+	transferBatchSize = 500
+
+	// maxImportErrors is the maximum number of row errors reported by an import
+	maxImportErrors = 100
+)
+
+// importRowError describes the error of a single row which couldn't be imported
+type importRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// HandleExportCollection is an endpoint handler which streams all the documents of a collection as ndjson or csv
+func HandleExportCollection(adminMan *admin.Manager, crud *crud.Module, schemaArg *schema.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		ctx := r.Context()
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+		project := vars["project"]
+		col := vars["col"]
+
+		format := transferFormat(r)
+
+		// The documents are read in batches after the cursor of the previous batch. The sort keys must identify a
+		// document uniquely, which is why the primary key is used by default
+		var sortKeys []string
+		if s := r.URL.Query().Get("sort"); s != "" {
+			sortKeys = strings.Split(s, ",")
+		} else {
+			sortKeys = schemaArg.GetPrimaryKeys(dbType, col)
+		}
+		if len(sortKeys) == 0 {
+			sortKeys = []string{"id"}
+			if t, err := crud.GetDBType(dbType); err == nil && t == string(utils.Mongo) {
+				sortKeys = []string{"_id"}
+			}
+		}
+
+		docs, cursor, err := readExportBatch(ctx, crud, dbType, project, col, sortKeys, nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		// The csv columns are the fields of the schema or the fields of the first batch if there is no schema
+		columns, ok := schemaArg.GetFieldNames(dbType, col)
+		if !ok {
+			columns = docFields(docs)
+		}
+
+		writer, err := utils.NewRowWriter(format, w, columns)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		if format == utils.FormatCSV {
+			w.Header().Set("Content-Type", "text/csv")
+		} else {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", col, format))
+		w.WriteHeader(http.StatusOK)
+
+		// The status has been sent already. Errors from here on can only end the stream
+		for {
+			for _, doc := range docs {
+				if err := writer.Write(doc); err != nil {
+					log.Println("Export error: could not write document -", err)
+					return
+				}
+			}
+
+			if len(docs) < transferBatchSize {
+				break
+			}
+
+			docs, cursor, err = readExportBatch(ctx, crud, dbType, project, col, sortKeys, &cursor)
+			if err != nil {
+				log.Println("Export error: could not read documents -", err)
+				return
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			log.Println("Export error: could not write documents -", err)
+		}
+	}
+}
+
+// readExportBatch reads the next batch of documents to be exported. The documents are read from the primary database
+// without going through the read cache, which a bulk export would only flush. It returns the cursor of the last
+// document read
+func readExportBatch(ctx context.Context, crud *crud.Module, dbType, project, col string, sortKeys []string, after *string) ([]map[string]interface{}, string, error) {
+	limit := int64(transferBatchSize)
+	req := &model.ReadRequest{
+		Find:      map[string]interface{}{},
+		Operation: utils.All,
+		Options:   &model.ReadOptions{Sort: sortKeys, Limit: &limit, After: after, WithDeleted: true},
+	}
+
+	result, err := crud.InternalRead(ctx, dbType, project, col, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results, _ := result.([]interface{})
+	docs := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		if doc, ok := result.(map[string]interface{}); ok {
+			docs = append(docs, doc)
+		}
+	}
+
+	if len(docs) == 0 {
+		return docs, "", nil
+	}
+	cursor, err := utils.EncodeCursor(sortKeys, docs[len(docs)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return docs, cursor, nil
+}
+
+// HandleImportCollection is an endpoint handler which inserts the documents streamed as ndjson or csv in a collection.
+// Every document is validated against the schema of the collection. Rows which fail don't stop the import and are
+// reported in the response instead
+func HandleImportCollection(adminMan *admin.Manager, crud *crud.Module, schemaArg *schema.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get the JWT token from header
+		token := utils.GetTokenFromHeader(r)
+		defer r.Body.Close()
+
+		// Check if the request is authorised
+		if err := adminMan.IsTokenValid(token); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		ctx := r.Context()
+		vars := mux.Vars(r)
+		dbType := vars["dbType"]
+		project := vars["project"]
+		col := vars["col"]
+
+		format := transferFormat(r)
+		reader, err := utils.NewRowReader(format, r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		var inserted, failed int
+		rowErrors := []importRowError{}
+		addError := func(row int, err error) {
+			failed++
+			if len(rowErrors) < maxImportErrors {
+				rowErrors = append(rowErrors, importRowError{Row: row, Error: err.Error()})
+			}
+		}
+
+		// Insert the documents of a chunk in one go. The documents are inserted one by one if the chunk fails to
+		// find out which of them failed
+		var docs []interface{}
+		var rows []int
+		insert := func() {
+			if len(docs) == 0 {
+				return
+			}
+			if err := importDocs(ctx, crud, dbType, project, col, docs); err == nil {
+				inserted += len(docs)
+			} else {
+				for i, doc := range docs {
+					if err := importDocs(ctx, crud, dbType, project, col, []interface{}{doc}); err != nil {
+						addError(rows[i], err)
+						continue
+					}
+					inserted++
+				}
+			}
+			docs, rows = nil, nil
+		}
+
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				if rowErr, ok := err.(*utils.RowError); ok {
+					addError(rowErr.Row, rowErr.Err)
+					continue
+				}
+
+				// The rest of the rows can't be read
+				insert()
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": err.Error(), "inserted": inserted, "failed": failed, "errors": rowErrors})
+				return
+			}
+
+			if format == utils.FormatCSV {
+				if err := schemaArg.ParseStringValues(dbType, col, doc); err != nil {
+					addError(reader.Row(), err)
+					continue
+				}
+			}
+
+			req := &model.CreateRequest{Document: doc, Operation: utils.One}
+			if err := schemaArg.ValidateCreateOperation(dbType, col, req); err != nil {
+				addError(reader.Row(), err)
+				continue
+			}
+
+			// The validated document is returned as an array
+			if validated, ok := req.Document.([]interface{}); ok && len(validated) == 1 {
+				docs = append(docs, validated[0])
+			} else {
+				docs = append(docs, req.Document)
+			}
+			rows = append(rows, reader.Row())
+
+			if len(docs) == transferBatchSize {
+				insert()
+			}
+		}
+		insert()
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{"inserted": inserted, "failed": failed, "errors": rowErrors})
+	}
+}
+
+// importDocs inserts the documents via a batch so that either all or none of them are inserted
+func importDocs(ctx context.Context, crud *crud.Module, dbType, project, col string, docs []interface{}) error {
+	req := &model.BatchRequest{Requests: []model.AllRequest{
+		{Type: string(utils.Create), Col: col, Document: docs, Operation: utils.All},
+	}}
+	return crud.Batch(ctx, dbType, project, req)
+}
+
+// transferFormat returns the format requested for an import or export. It defaults to ndjson
+func transferFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+	return utils.FormatNDJSON
+}
+
+// docFields returns the sorted union of the fields of the documents
+func docFields(docs []map[string]interface{}) []string {
+	seen := map[string]bool{}
+	fields := []string{}
+	for _, doc := range docs {
+		for field := range doc {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
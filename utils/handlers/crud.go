@@ -1,11 +1,9 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/gorilla/mux"
 
@@ -25,7 +23,7 @@ func HandleCrudCreate(auth *auth.Module, crud *crud.Module, realtime *realtime.M
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		ctx, cancel := crud.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		// Get the path parameters
@@ -65,7 +63,7 @@ func HandleCrudRead(auth *auth.Module, crud *crud.Module) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		ctx, cancel := crud.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		// Get the path parameters
@@ -90,7 +88,7 @@ func HandleCrudRead(auth *auth.Module, crud *crud.Module) http.HandlerFunc {
 		}
 
 		// Perform the read operation
-		result, err := crud.Read(ctx, meta.dbType, meta.project, meta.col, &req)
+		result, pageInfo, err := crud.Read(ctx, meta.dbType, meta.project, meta.col, &req)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -100,9 +98,14 @@ func HandleCrudRead(auth *auth.Module, crud *crud.Module) http.HandlerFunc {
 		// function to do postProcessing on result
 		_ = auth.PostProcessMethod(actions, result)
 
+		res := map[string]interface{}{"result": result}
+		if pageInfo != nil {
+			res["pageInfo"] = pageInfo
+		}
+
 		// Give positive acknowledgement
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]interface{}{"result": result})
+		json.NewEncoder(w).Encode(res)
 	}
 }
 
@@ -111,7 +114,7 @@ func HandleCrudUpdate(auth *auth.Module, crud *crud.Module, realtime *realtime.M
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		ctx, cancel := crud.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		// Get the path parameters
@@ -133,8 +136,12 @@ func HandleCrudUpdate(auth *auth.Module, crud *crud.Module, realtime *realtime.M
 		err = crud.Update(ctx, meta.dbType, meta.project, meta.col, &req)
 		if err != nil {
 
-			// Send http response
-			w.WriteHeader(http.StatusInternalServerError)
+			// Send http response. A version conflict means the client needs to read the document again
+			status := http.StatusInternalServerError
+			if err == utils.ErrVersionConflict {
+				status = http.StatusConflict
+			}
+			w.WriteHeader(status)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
 		}
@@ -150,7 +157,7 @@ func HandleCrudDelete(auth *auth.Module, crud *crud.Module, realtime *realtime.M
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		ctx, cancel := crud.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		// Get the path parameters
@@ -188,7 +195,7 @@ func HandleCrudAggregate(auth *auth.Module, crud *crud.Module) http.HandlerFunc
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		ctx, cancel := crud.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		// Get the path parameters
@@ -242,7 +249,7 @@ func getRequestMetaData(r *http.Request) *requestMetaData {
 func HandleCrudBatch(auth *auth.Module, crud *crud.Module, realtime *realtime.Module) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		ctx, cancel := crud.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		// Get the path parameters
@@ -286,6 +293,22 @@ func HandleCrudBatch(auth *auth.Module, crud *crud.Module, realtime *realtime.Mo
 		// Perform the batch operation
 		err := crud.Batch(ctx, meta.dbType, meta.project, &txRequest)
 		if err != nil {
+			// Let the client know which request of the batch failed. A version conflict means the client needs to
+			// read the document again
+			if batchErr, ok := err.(*utils.BatchError); ok {
+				status := http.StatusInternalServerError
+				if batchErr.Err == utils.ErrVersionConflict {
+					status = http.StatusConflict
+				}
+				w.WriteHeader(status)
+				res := map[string]interface{}{"error": batchErr.Error(), "index": batchErr.Index}
+				if len(batchErr.Counts) > 0 {
+					// The requests before the failing one were applied and could not be rolled back
+					res["counts"] = batchErr.Counts
+				}
+				json.NewEncoder(w).Encode(res)
+				return
+			}
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 			return
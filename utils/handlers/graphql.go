@@ -1,11 +1,10 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"log"
 	"net/http"
-	"time"
+	"sync"
 
 	"github.com/gorilla/mux"
 
@@ -18,7 +17,7 @@ func HandleGraphQLRequest(graphql *graphql.Module) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Create a context of execution
-		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		ctx, cancel := graphql.WithRequestTimeout(r.Context())
 		defer cancel()
 
 		vars := mux.Vars(r)
@@ -42,28 +41,42 @@ func HandleGraphQLRequest(graphql *graphql.Module) http.HandlerFunc {
 		// Get the path parameters
 		token := getRequestMetaData(r).token
 
+		// The response is written either by the query or on expiry of the request, whichever happens first
+		var once sync.Once
+		respond := func(status int, res interface{}) {
+			once.Do(func() {
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(res)
+			})
+		}
+
 		ch := make(chan struct{}, 1)
 
-		graphql.ExecGraphQLQuery(ctx, &req, token, func(op interface{}, err error) {
+		graphql.ExecGraphQLQuery(ctx, &req, token, func(op interface{}, pageInfo map[string]*model.PageInfo, err error) {
 			defer func() { ch <- struct{}{} }()
 			if err != nil {
 				errMes := map[string]interface{}{"message": err.Error()}
-				json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{errMes}})
+				respond(http.StatusOK, map[string]interface{}{"errors": []interface{}{errMes}})
 				return
 			}
 
-			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode(map[string]interface{}{"data": op})
+			res := map[string]interface{}{"data": op}
+			if pageInfo != nil {
+				// The cursors of the sorted reads are returned so that the next page can be read
+				res["extensions"] = map[string]interface{}{"pageInfo": pageInfo}
+			}
+
+			respond(http.StatusOK, res)
 			return
 		})
 
 		select {
 		case <-ch:
 			return
-		case <-time.After(10 * time.Second):
-			log.Println("GraphQL Handler: Request timed out")
-			errMes := map[string]interface{}{"message": "GraphQL Handler: Request timed out"}
-			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{errMes}})
+		case <-ctx.Done():
+			log.Println("GraphQL Handler: Request cancelled")
+			errMes := map[string]interface{}{"message": "request timed out"}
+			respond(http.StatusGatewayTimeout, map[string]interface{}{"errors": []interface{}{errMes}})
 			return
 		}
 	}
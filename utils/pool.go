@@ -0,0 +1,26 @@
+package utils
+
+import "time"
+
+// DefaultStatementTimeout is the timeout of a database operation when no statement timeout has been configured
+const DefaultStatementTimeout = 10 * time.Second
+
+// DefaultSlowQueryThreshold is the duration after which an operation is logged as slow when no threshold has been configured
+const DefaultSlowQueryThreshold = time.Second
+
+// PoolConfig holds the connection pool settings of a database. Zero values leave the defaults of the driver in place
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// PoolStats describes the current state of the connection pool of a database
+type PoolStats struct {
+	MaxOpenConns int   `json:"maxOpenConns"`
+	OpenConns    int   `json:"openConns"`
+	InUse        int   `json:"inUse"`
+	Idle         int   `json:"idle"`
+	WaitCount    int64 `json:"waitCount"`
+	WaitDuration int64 `json:"waitDuration"` // in milliseconds
+}
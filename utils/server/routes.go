@@ -46,15 +46,23 @@ func (s *Server) routes(router *mux.Router, profiler bool, staticPath string) {
 
 	// Initialize route for getting database config
 	router.Methods("GET").Path("/v1/config/projects/{project}/database/{dbType}/connection-state").HandlerFunc(handlers.HandleGetConnectionState(s.adminMan, s.crud))
+	router.Methods("GET").Path("/v1/config/projects/{project}/database/{dbType}/slow-queries").HandlerFunc(handlers.HandleGetSlowQueries(s.adminMan, s.crud))
 	router.Methods("GET").Path("/v1/config/projects/{project}/database/{dbType}/list-collections").HandlerFunc(handlers.HandleGetCollections(s.adminMan, s.crud, s.syncMan)) // TODO: Check response type
 	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/rules").HandlerFunc(handlers.HandleCollectionRules(s.adminMan, s.syncMan))
 	router.Methods("DELETE").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}").HandlerFunc(handlers.HandleDeleteCollection(s.adminMan, s.crud, s.syncMan))
+	router.Methods("GET").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/export").HandlerFunc(handlers.HandleExportCollection(s.adminMan, s.crud, s.schema))
+	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/import").HandlerFunc(handlers.HandleImportCollection(s.adminMan, s.crud, s.schema))
+	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/seed").HandlerFunc(handlers.HandleSeedDatabase(s.adminMan, s.schema))
+	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/explain").HandlerFunc(handlers.HandleExplainRead(s.adminMan, s.crud))
+	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/soft-delete/{action}").HandlerFunc(handlers.HandleSoftDeletedDocs(s.adminMan, s.crud))
 	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/config").HandlerFunc(handlers.HandleDatabaseConnection(s.adminMan, s.crud, s.syncMan))
 	router.Methods("DELETE").Path("/v1/config/projects/{project}/database/{dbType}").HandlerFunc(handlers.HandleRemoveDatabaseConfig(s.adminMan, s.crud, s.syncMan))
 	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/modify-schema").HandlerFunc(handlers.HandleModifyAllSchema(s.adminMan, s.schema, s.syncMan))
 	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/modify-schema").HandlerFunc(handlers.HandleModifySchema(s.adminMan, s.schema, s.syncMan))
+	router.Methods("GET").Path("/v1/config/projects/{project}/database/{dbType}/migrations").HandlerFunc(handlers.HandleGetMigrations(s.adminMan, s.schema))
 	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/reload-schema").HandlerFunc(handlers.HandleReloadSchema(s.adminMan, s.schema, s.syncMan))
 	router.Methods("GET").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/inspect-schema").HandlerFunc(handlers.HandleSchemaInspection(s.adminMan, s.schema, s.syncMan))
+	router.Methods("POST").Path("/v1/config/projects/{project}/database/{dbType}/collections/{col}/refresh-view").HandlerFunc(handlers.HandleRefreshView(s.adminMan, s.schema))
 
 	// Initialize route for getting all schemas for all the collections present in config.crud
 	router.Methods("GET").Path("/v1/config/inspect/{project}/{dbType}").HandlerFunc(handlers.HandleGetCollectionSchemas(s.adminMan, s.schema))
@@ -70,6 +70,7 @@ func New(nodeID, clusterID, advertiseAddr, storeType string, removeProjectScope
 	}
 
 	s := schema.Init(c, removeProjectScope)
+	c.SetSchema(s)
 	a := auth.Init(nodeID, c, s, removeProjectScope)
 
 	fn := functions.Init(a, syncMan)
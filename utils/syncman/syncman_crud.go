@@ -41,11 +41,19 @@ func (s *Manager) SetDatabaseConnection(ctx context.Context, project, dbType str
 	// update database config
 	coll, ok := projectConfig.Modules.Crud[dbType]
 	if !ok {
-		projectConfig.Modules.Crud[dbType] = &config.CrudStub{Conn: v.Conn, Enabled: v.Enabled, Collections: map[string]*config.TableRule{}, Type: v.Type}
+		projectConfig.Modules.Crud[dbType] = &config.CrudStub{Conn: v.Conn, Enabled: v.Enabled, Collections: map[string]*config.TableRule{}, Type: v.Type, BatchMode: v.BatchMode, Replicas: v.Replicas,
+			MaxOpenConns: v.MaxOpenConns, MaxIdleConns: v.MaxIdleConns, ConnMaxLifetime: v.ConnMaxLifetime, StatementTimeout: v.StatementTimeout, CDC: v.CDC}
 	} else {
 		coll.Conn = v.Conn
 		coll.Enabled = v.Enabled
 		coll.Type = v.Type
+		coll.BatchMode = v.BatchMode
+		coll.Replicas = v.Replicas
+		coll.MaxOpenConns = v.MaxOpenConns
+		coll.MaxIdleConns = v.MaxIdleConns
+		coll.ConnMaxLifetime = v.ConnMaxLifetime
+		coll.StatementTimeout = v.StatementTimeout
+		coll.CDC = v.CDC
 	}
 
 	return s.setProject(ctx, projectConfig)
@@ -67,7 +75,7 @@ func (s *Manager) RemoveDatabaseConfig(ctx context.Context, project, dbAlias str
 	return s.setProject(ctx, projectConfig)
 }
 
-func (s *Manager) SetModifySchema(ctx context.Context, project, dbType, col, schema string) error {
+func (s *Manager) SetModifySchema(ctx context.Context, project, dbType, col, schema string, view *config.View) error {
 	// Acquire a lock
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -85,9 +93,13 @@ func (s *Manager) SetModifySchema(ctx context.Context, project, dbType, col, sch
 	temp, ok := collection.Collections[col]
 	// if collection doesn't exist then add to config
 	if !ok {
-		collection.Collections[col] = &config.TableRule{Schema: schema, Rules: map[string]*config.Rule{}} // TODO: rule field here is null
+		collection.Collections[col] = &config.TableRule{Schema: schema, View: view, Rules: map[string]*config.Rule{}} // TODO: rule field here is null
 	} else {
 		temp.Schema = schema
+		// The view is left as is if the request doesn't provide one, as done while modifying the schema
+		if view != nil {
+			temp.View = view
+		}
 	}
 
 	return s.setProject(ctx, projectConfig)
@@ -112,11 +124,13 @@ func (s *Manager) SetCollectionRules(ctx context.Context, project, dbType, col s
 		if databaseConfig.Collections == nil {
 			databaseConfig.Collections = map[string]*config.TableRule{col: v}
 		} else {
-			databaseConfig.Collections[col] = &config.TableRule{IsRealTimeEnabled: v.IsRealTimeEnabled, Rules: v.Rules}
+			databaseConfig.Collections[col] = &config.TableRule{IsRealTimeEnabled: v.IsRealTimeEnabled, Rules: v.Rules, SoftDelete: v.SoftDelete, Cache: v.Cache}
 		}
 	} else {
 		collection.IsRealTimeEnabled = v.IsRealTimeEnabled
 		collection.Rules = v.Rules
+		collection.SoftDelete = v.SoftDelete
+		collection.Cache = v.Cache
 	}
 	return s.setProject(ctx, projectConfig)
 }
@@ -180,7 +194,7 @@ func (s *Manager) SetSchemaInspection(ctx context.Context, project, dbType, col,
 	return s.setProject(ctx, projectConfig)
 }
 
-func (s *Manager) SetModifyAllSchema(ctx context.Context, dbType, project string, schemaArg *schema.Schema, v config.CrudStub) error {
+func (s *Manager) SetModifyAllSchema(ctx context.Context, dbType, project, appliedBy string, schemaArg *schema.Schema, v config.CrudStub) error {
 	// Acquire a lock
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -196,7 +210,7 @@ func (s *Manager) SetModifyAllSchema(ctx context.Context, dbType, project string
 		return errors.New("specified database not present in config")
 	}
 
-	if err := schemaArg.SchemaModifyAll(ctx, dbType, project, v.Collections); err != nil {
+	if err := schemaArg.SchemaModifyAll(ctx, dbType, project, appliedBy, v.Collections); err != nil {
 		return err
 	}
 
@@ -204,9 +218,12 @@ func (s *Manager) SetModifyAllSchema(ctx context.Context, dbType, project string
 		temp, ok := collection.Collections[colName]
 		// if collection doesn't exist then add to config
 		if !ok {
-			collection.Collections[colName] = &config.TableRule{Schema: colValue.Schema} // TODO: rule field here is null
+			collection.Collections[colName] = &config.TableRule{Schema: colValue.Schema, View: colValue.View} // TODO: rule field here is null
 		} else {
 			temp.Schema = colValue.Schema
+			if colValue.View != nil {
+				temp.View = colValue.View
+			}
 		}
 	}
 
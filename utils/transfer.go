@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	// FormatNDJSON is the format in which every line holds a JSON document
+	FormatNDJSON string = "ndjson"
+
+	// FormatCSV is the format in which every line holds the comma separated values of a document
+	FormatCSV string = "csv"
+)
+
+// maxNDJSONLineSize is the maximum size of a single document being imported as NDJSON
+const maxNDJSONLineSize = 16 * 1024 * 1024
+
+// RowWriter writes the documents of a collection being exported
+type RowWriter interface {
+	Write(doc map[string]interface{}) error
+	Flush() error
+}
+
+// RowReader reads the documents of a collection being imported. It returns io.EOF once all the rows are read. The
+// errors of malformed rows are returned as a *RowError after which the next row can be read
+type RowReader interface {
+	Read() (map[string]interface{}, error)
+
+	// Row returns the number of the row last read
+	Row() int
+}
+
+// RowError is returned when a single row being imported is malformed
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row (%d) is invalid: %v", e.Row, e.Err)
+}
+
+// NewRowWriter creates a writer for the provided format. The columns are only used by the csv format, where they
+// are written as the header
+func NewRowWriter(format string, w io.Writer, columns []string) (RowWriter, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonWriter{w: bufio.NewWriter(w)}, nil
+	case FormatCSV:
+		writer := &csvWriter{w: csv.NewWriter(w), columns: columns}
+		if err := writer.w.Write(columns); err != nil {
+			return nil, err
+		}
+		return writer, nil
+	default:
+		return nil, fmt.Errorf("invalid format (%s) provided", format)
+	}
+}
+
+// NewRowReader creates a reader for the provided format. The values of the csv format are read as strings
+func NewRowReader(format string, r io.Reader) (RowReader, error) {
+	switch format {
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), maxNDJSONLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("could not read the csv header: %v", err)
+		}
+		return &csvReader{r: reader, columns: header, row: 1}, nil
+	default:
+		return nil, fmt.Errorf("invalid format (%s) provided", format)
+	}
+}
+
+type ndjsonWriter struct {
+	w *bufio.Writer
+}
+
+func (n *ndjsonWriter) Write(doc map[string]interface{}) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if _, err := n.w.Write(data); err != nil {
+		return err
+	}
+	return n.w.WriteByte('\n')
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (c *csvWriter) Write(doc map[string]interface{}) error {
+	record := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		value, err := csvValue(doc[column])
+		if err != nil {
+			return err
+		}
+		record[i] = value
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// csvValue formats a value as a csv field. Objects and arrays are written as JSON
+func csvValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case int, int32, int64, float32, float64:
+		return fmt.Sprint(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		// Values like object ids get marshalled to JSON strings
+		if s, err := strconv.Unquote(string(data)); err == nil {
+			return s, nil
+		}
+		return string(data), nil
+	}
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func (n *ndjsonReader) Read() (map[string]interface{}, error) {
+	for n.scanner.Scan() {
+		n.row++
+
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		doc := map[string]interface{}{}
+		if err := json.Unmarshal(line, &doc); err != nil {
+			return nil, &RowError{Row: n.row, Err: err}
+		}
+		return doc, nil
+	}
+
+	if err := n.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (n *ndjsonReader) Row() int {
+	return n.row
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns []string
+	row     int
+}
+
+func (c *csvReader) Read() (map[string]interface{}, error) {
+	record, err := c.r.Read()
+	if err != nil {
+		if parseErr, ok := err.(*csv.ParseError); ok {
+			c.row++
+			return nil, &RowError{Row: c.row, Err: parseErr.Err}
+		}
+		return nil, err
+	}
+	c.row++
+
+	if len(record) != len(c.columns) {
+		return nil, &RowError{Row: c.row, Err: fmt.Errorf("expected %d fields but got %d", len(c.columns), len(record))}
+	}
+
+	// Empty fields are left out of the document
+	doc := make(map[string]interface{}, len(record))
+	for i, value := range record {
+		if value != "" {
+			doc[c.columns[i]] = value
+		}
+	}
+	return doc, nil
+}
+
+// Row returns the number of the row last read. The header of a csv counts as a row
+func (c *csvReader) Row() int {
+	return c.row
+}
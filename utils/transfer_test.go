@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRowWriter(t *testing.T) {
+	docs := []map[string]interface{}{
+		{"id": "1", "count": int64(5), "done": true, "tags": []interface{}{"a", "b"}},
+		{"id": "2", "text": "hello, world"},
+	}
+
+	var tests = []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatNDJSON,
+			want:   "{\"count\":5,\"done\":true,\"id\":\"1\",\"tags\":[\"a\",\"b\"]}\n{\"id\":\"2\",\"text\":\"hello, world\"}\n",
+		},
+		{
+			format: FormatCSV,
+			want:   "id,count,done,tags,text\n1,5,true,\"[\"\"a\"\",\"\"b\"\"]\",\n2,,,,\"hello, world\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			writer, err := NewRowWriter(test.format, buf, []string{"id", "count", "done", "tags", "text"})
+			if err != nil {
+				t.Fatal("NewRowWriter() error:", err)
+			}
+			for _, doc := range docs {
+				if err := writer.Write(doc); err != nil {
+					t.Fatal("Write() error:", err)
+				}
+			}
+			if err := writer.Flush(); err != nil {
+				t.Fatal("Flush() error:", err)
+			}
+
+			if got := buf.String(); got != test.want {
+				t.Errorf("got %q; want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRowReader(t *testing.T) {
+	var tests = []struct {
+		format    string
+		data      string
+		wantDocs  []map[string]interface{}
+		wantRows  []int
+		errorRows []int
+	}{
+		{
+			format:    FormatNDJSON,
+			data:      "{\"id\":\"1\",\"count\":5}\n\n{\"id\":\n{\"id\":\"3\"}\n",
+			wantDocs:  []map[string]interface{}{{"id": "1", "count": float64(5)}, {"id": "3"}},
+			wantRows:  []int{1, 4},
+			errorRows: []int{3},
+		},
+		{
+			format:    FormatCSV,
+			data:      "id,text\n1,first\n2\n3,\n",
+			wantDocs:  []map[string]interface{}{{"id": "1", "text": "first"}, {"id": "3"}},
+			wantRows:  []int{2, 4},
+			errorRows: []int{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			reader, err := NewRowReader(test.format, strings.NewReader(test.data))
+			if err != nil {
+				t.Fatal("NewRowReader() error:", err)
+			}
+
+			var docs []map[string]interface{}
+			var rows, errorRows []int
+			for {
+				doc, err := reader.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					rowErr, ok := err.(*RowError)
+					if !ok {
+						t.Fatal("Read() error:", err)
+					}
+					errorRows = append(errorRows, rowErr.Row)
+					continue
+				}
+				docs = append(docs, doc)
+				rows = append(rows, reader.Row())
+			}
+
+			if !reflect.DeepEqual(docs, test.wantDocs) {
+				t.Errorf("got documents %v; want %v", docs, test.wantDocs)
+			}
+			if !reflect.DeepEqual(rows, test.wantRows) {
+				t.Errorf("got rows %v; want %v", rows, test.wantRows)
+			}
+			if !reflect.DeepEqual(errorRows, test.errorRows) {
+				t.Errorf("got error rows %v; want %v", errorRows, test.errorRows)
+			}
+		})
+	}
+}
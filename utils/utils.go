@@ -1,6 +1,9 @@
 package utils
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 // AcceptableIDType converts a provied id to string
 func AcceptableIDType(id interface{}) (string, bool) {
@@ -33,3 +36,31 @@ func GetIDVariable(dbType string) string {
 
 	return idVar
 }
+
+// GenerateUpsertDoc generates the document which gets inserted by an upsert operation when no document matches the find
+// clause. It is made up of the equality conditions of the find clause and the fields being set by the update
+func GenerateUpsertDoc(find, update map[string]interface{}) map[string]interface{} {
+	doc := map[string]interface{}{}
+	for k, v := range find {
+		if strings.HasPrefix(k, "$") {
+			continue
+		}
+
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			doc[k] = v
+			continue
+		}
+		if value, p := obj["$eq"]; p {
+			doc[k] = value
+		}
+	}
+
+	if set, ok := update["$set"].(map[string]interface{}); ok {
+		for k, v := range set {
+			doc[k] = v
+		}
+	}
+
+	return doc
+}
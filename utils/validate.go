@@ -5,6 +5,7 @@ import (
 	"log"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -65,13 +66,20 @@ func Validate(where map[string]interface{}, obj interface{}) bool {
 			}
 
 			val, p := res[k]
-			if !p {
-				return false
-			}
 
 			// And clause
 			cond, ok := temp.(map[string]interface{})
 			if !ok {
+				// A null value matches the documents in which the field is null or absent
+				if temp == nil {
+					if val != nil {
+						return false
+					}
+					continue
+				}
+				if !p {
+					return false
+				}
 				temp, val = adjustValTypes(temp, val)
 				if !compare(temp, val) {
 					return false
@@ -81,113 +89,7 @@ func Validate(where map[string]interface{}, obj interface{}) bool {
 
 			// match condition
 			for k2, v2 := range cond {
-				v2, val = adjustValTypes(v2, val)
-				if reflect.TypeOf(val) != reflect.TypeOf(v2) {
-					return false
-				}
-				switch k2 {
-				case "$eq":
-					if !compare(val, v2) {
-						return false
-					}
-				case "$ne":
-					if compare(val, v2) {
-						return false
-					}
-				case "$gt":
-					switch val2 := val.(type) {
-					case string:
-						v2String := v2.(string)
-						if val2 <= v2String {
-							return false
-						}
-					case int64:
-						v2Int := v2.(int64)
-						if val2 <= v2Int {
-							return false
-						}
-					case float64:
-						v2Float := v2.(float64)
-						if val2 <= v2Float {
-							return false
-						}
-					default:
-						return false
-					}
-				case "$gte":
-					switch val2 := val.(type) {
-					case string:
-						v2String := v2.(string)
-						if val2 < v2String {
-							return false
-						}
-					case int64:
-						v2Int := v2.(int64)
-						if val2 < v2Int {
-							return false
-						}
-					case float64:
-						v2Float := v2.(float64)
-						if val2 < v2Float {
-							return false
-						}
-					default:
-						return false
-					}
-
-				case "$lt":
-					switch val2 := val.(type) {
-					case string:
-						v2String := v2.(string)
-						if val2 >= v2String {
-							return false
-						}
-					case int64:
-						v2Int := v2.(int64)
-						if val2 >= v2Int {
-							return false
-						}
-					case float64:
-						v2Float := v2.(float64)
-						if val2 >= v2Float {
-							return false
-						}
-					default:
-						return false
-					}
-
-				case "$lte":
-					switch val2 := val.(type) {
-					case string:
-						v2String := v2.(string)
-						if val2 > v2String {
-							return false
-						}
-					case int64:
-						v2Int := v2.(int64)
-						if val2 > v2Int {
-							return false
-						}
-					case float64:
-						v2Float := v2.(float64)
-						if val2 > v2Float {
-							return false
-						}
-					default:
-						return false
-					}
-
-				case "$regex":
-					regex := v2.(string)
-					vString := val.(string)
-					r, err := regexp.Compile(regex)
-					if err != nil {
-						log.Println("Couldn't compile regex")
-						return false
-					}
-					return r.MatchString(vString)
-				default:
-					log.Printf("Invalid operator (%s) provided\n", k2)
+				if !validateOperator(k2, v2, val, p) {
 					return false
 				}
 			}
@@ -208,3 +110,235 @@ func Validate(where map[string]interface{}, obj interface{}) bool {
 	}
 	return false
 }
+
+// isNullCheck checks if the operator tests whether a field is null
+func isNullCheck(op string, v interface{}) bool {
+	switch op {
+	case "$exists":
+		return true
+	case "$eq", "$ne":
+		return v == nil
+	}
+	return false
+}
+
+// validateOperator checks if the value of the field satisfies the operator. The present flag indicates whether
+// the field exists in the document
+func validateOperator(op string, v2, val interface{}, present bool) bool {
+	// Process the operators which are valid on absent fields and null values first
+	switch op {
+	case "$exists":
+		exists, ok := v2.(bool)
+		if !ok {
+			return false
+		}
+		return exists == (present && val != nil)
+
+	case "$not":
+		cond, ok := v2.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for op2, v3 := range cond {
+			// A comparison with a null value is unknown in SQL, and so is its negation. Hence null and absent
+			// values only satisfy the negation of the operators checking for null
+			if (!present || val == nil) && !isNullCheck(op2, v3) {
+				return false
+			}
+			if !validateOperator(op2, v3, val, present) {
+				return true
+			}
+		}
+		return false
+
+	case "$eq":
+		if v2 == nil {
+			return val == nil
+		}
+
+	case "$ne":
+		if v2 == nil {
+			return val != nil
+		}
+	}
+
+	if !present || val == nil {
+		return false
+	}
+
+	switch op {
+	case "$in", "$nin":
+		array, ok := v2.([]interface{})
+		if !ok {
+			return false
+		}
+		found := false
+		for _, item := range array {
+			item, v := adjustValTypes(item, val)
+			if reflect.TypeOf(item) == reflect.TypeOf(v) && compare(v, item) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "$in")
+
+	case "$between":
+		array, ok := v2.([]interface{})
+		if !ok || len(array) != 2 {
+			return false
+		}
+		return validateOperator("$gte", array[0], val, present) && validateOperator("$lte", array[1], val, present)
+
+	case "$like", "$ilike":
+		pattern, ok1 := v2.(string)
+		vString, ok2 := val.(string)
+		if !ok1 || !ok2 {
+			return false
+		}
+		r, err := regexp.Compile(likeToRegex(pattern, op == "$ilike"))
+		if err != nil {
+			log.Println("Couldn't compile like pattern")
+			return false
+		}
+		return r.MatchString(vString)
+
+	case "$contains":
+		return contains(val, v2)
+	}
+
+	v2, val = adjustValTypes(v2, val)
+	if reflect.TypeOf(val) != reflect.TypeOf(v2) {
+		return false
+	}
+	switch op {
+	case "$eq":
+		return compare(val, v2)
+	case "$ne":
+		return !compare(val, v2)
+	case "$gt":
+		switch val2 := val.(type) {
+		case string:
+			return val2 > v2.(string)
+		case int64:
+			return val2 > v2.(int64)
+		case float64:
+			return val2 > v2.(float64)
+		default:
+			return false
+		}
+	case "$gte":
+		switch val2 := val.(type) {
+		case string:
+			return val2 >= v2.(string)
+		case int64:
+			return val2 >= v2.(int64)
+		case float64:
+			return val2 >= v2.(float64)
+		default:
+			return false
+		}
+
+	case "$lt":
+		switch val2 := val.(type) {
+		case string:
+			return val2 < v2.(string)
+		case int64:
+			return val2 < v2.(int64)
+		case float64:
+			return val2 < v2.(float64)
+		default:
+			return false
+		}
+
+	case "$lte":
+		switch val2 := val.(type) {
+		case string:
+			return val2 <= v2.(string)
+		case int64:
+			return val2 <= v2.(int64)
+		case float64:
+			return val2 <= v2.(float64)
+		default:
+			return false
+		}
+
+	case "$regex":
+		regex := v2.(string)
+		vString := val.(string)
+		r, err := regexp.Compile(regex)
+		if err != nil {
+			log.Println("Couldn't compile regex")
+			return false
+		}
+		return r.MatchString(vString)
+	default:
+		log.Printf("Invalid operator (%s) provided\n", op)
+		return false
+	}
+}
+
+// likeToRegex converts a sql LIKE pattern to a regular expression
+func likeToRegex(pattern string, caseInsensitive bool) string {
+	var b strings.Builder
+	if caseInsensitive {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// contains checks if an array contains the value (or all the values if the value is an array itself)
+// and if an object contains all the fields of the value
+func contains(val, v2 interface{}) bool {
+	switch value := val.(type) {
+	case []interface{}:
+		items, ok := v2.([]interface{})
+		if !ok {
+			items = []interface{}{v2}
+		}
+		for _, item := range items {
+			found := false
+			for _, elem := range value {
+				if contains(elem, item) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+
+	case map[string]interface{}:
+		obj, ok := v2.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, item := range obj {
+			elem, p := value[k]
+			if !p || !contains(elem, item) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		v2, val = adjustValTypes(v2, val)
+		if reflect.TypeOf(val) != reflect.TypeOf(v2) {
+			return false
+		}
+		return compare(val, v2)
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	doc := map[string]interface{}{
+		"name":  "Space Cloud",
+		"age":   int64(5),
+		"score": 7.5,
+		"tags":  []interface{}{"go", "db"},
+		"meta":  map[string]interface{}{"stars": int64(10), "lang": "go"},
+		"owner": nil,
+	}
+
+	var tests = []struct {
+		name  string
+		where map[string]interface{}
+		want  bool
+	}{
+		{name: "equality", where: map[string]interface{}{"name": "Space Cloud", "age": 5}, want: true},
+		{name: "greater than", where: map[string]interface{}{"age": map[string]interface{}{"$gt": 6}}, want: false},
+		{name: "in", where: map[string]interface{}{"age": map[string]interface{}{"$in": []interface{}{1, 5}}}, want: true},
+		{name: "not in", where: map[string]interface{}{"age": map[string]interface{}{"$nin": []interface{}{1, 5}}}, want: false},
+		{name: "like", where: map[string]interface{}{"name": map[string]interface{}{"$like": "Space%"}}, want: true},
+		{name: "like is case sensitive", where: map[string]interface{}{"name": map[string]interface{}{"$like": "space%"}}, want: false},
+		{name: "ilike", where: map[string]interface{}{"name": map[string]interface{}{"$ilike": "space_cloud"}}, want: true},
+		{name: "exists", where: map[string]interface{}{"age": map[string]interface{}{"$exists": true}}, want: true},
+		{name: "exists on null field", where: map[string]interface{}{"owner": map[string]interface{}{"$exists": true}}, want: false},
+		{name: "not exists on absent field", where: map[string]interface{}{"missing": map[string]interface{}{"$exists": false}}, want: true},
+		{name: "null equality", where: map[string]interface{}{"missing": nil}, want: true},
+		{name: "not null", where: map[string]interface{}{"owner": map[string]interface{}{"$ne": nil}}, want: false},
+		{name: "between", where: map[string]interface{}{"score": map[string]interface{}{"$between": []interface{}{7.0, 8.0}}}, want: true},
+		{name: "not between", where: map[string]interface{}{"age": map[string]interface{}{"$between": []interface{}{6, 8}}}, want: false},
+		{name: "contains scalar", where: map[string]interface{}{"tags": map[string]interface{}{"$contains": "db"}}, want: true},
+		{name: "contains array", where: map[string]interface{}{"tags": map[string]interface{}{"$contains": []interface{}{"go", "sql"}}}, want: false},
+		{name: "contains object", where: map[string]interface{}{"meta": map[string]interface{}{"$contains": map[string]interface{}{"lang": "go"}}}, want: true},
+		{name: "not", where: map[string]interface{}{"age": map[string]interface{}{"$not": map[string]interface{}{"$gt": 10}}}, want: true},
+		{name: "not matching", where: map[string]interface{}{"age": map[string]interface{}{"$not": map[string]interface{}{"$lt": 10}}}, want: false},
+		{name: "not on absent field", where: map[string]interface{}{"missing": map[string]interface{}{"$not": map[string]interface{}{"$gt": 10}}}, want: false},
+		{name: "not on null field", where: map[string]interface{}{"owner": map[string]interface{}{"$not": map[string]interface{}{"$eq": "me"}}}, want: false},
+		{name: "not exists on null field", where: map[string]interface{}{"owner": map[string]interface{}{"$not": map[string]interface{}{"$exists": true}}}, want: true},
+		{name: "not null equality on null field", where: map[string]interface{}{"owner": map[string]interface{}{"$not": map[string]interface{}{"$eq": nil}}}, want: false},
+		{name: "not null inequality on absent field", where: map[string]interface{}{"missing": map[string]interface{}{"$not": map[string]interface{}{"$ne": nil}}}, want: true},
+		{name: "unknown operator", where: map[string]interface{}{"age": map[string]interface{}{"$size": 1}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.where, doc); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}